@@ -3,43 +3,94 @@ package main
 import (
     "log"
     "net/http"
-    "net/http/httputil"
-    "net/url"
     "os"
+    "os/signal"
+    "strings"
+    "syscall"
+    "time"
+
+    "jobfair-api-gateway/internal/config"
+    "jobfair-api-gateway/internal/middleware"
+    "jobfair-api-gateway/internal/proxy"
+    "jobfair-api-gateway/internal/ratelimit"
+    "jobfair-shared-libs/jwks"
 
     "github.com/gin-gonic/gin"
 )
 
-type ServiceConfig struct {
-    Name string `yaml:"name"`
-    URL  string `yaml:"url"`
-    Path string `yaml:"path"`
-}
-
-type Config struct {
-    Services []ServiceConfig `yaml:"services"`
-}
-
 func main() {
     router := gin.Default()
 
-    // Auth Service Proxy
-    authURL, _ := url.Parse(os.Getenv("AUTH_SERVICE_URL"))
-    authProxy := httputil.NewSingleHostReverseProxy(authURL)
-    
-    // Company Service Proxy
-    companyURL, _ := url.Parse(os.Getenv("COMPANY_SERVICE_URL"))
-    companyProxy := httputil.NewSingleHostReverseProxy(companyURL)
+    // Only trust X-Forwarded-For from known proxies, otherwise clients could
+    // pick their own rate limit key
+    var trustedProxies []string
+    if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+        trustedProxies = strings.Split(proxies, ",")
+    }
+    if err := router.SetTrustedProxies(trustedProxies); err != nil {
+        log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+    }
+
+    // Access tokens are verified with the auth service public keys
+    jwksURL := os.Getenv("JWKS_URL")
+    if jwksURL == "" {
+        authServiceURL := os.Getenv("AUTH_SERVICE_URL")
+        if authServiceURL == "" {
+            log.Fatal("JWKS_URL or AUTH_SERVICE_URL is required")
+        }
+        jwksURL = strings.TrimSuffix(authServiceURL, "/") + "/.well-known/jwks.json"
+    }
+    keys := jwks.NewKeySet(jwksURL, 10*time.Minute)
+
+    // Shared secret that lets downstream services trust X-User-* headers
+    gatewaySecret := os.Getenv("GATEWAY_SECRET")
 
-    // Routes
-    router.Any("/api/v1/auth/*path", gin.WrapH(authProxy))
-    router.Any("/api/v1/companies/*path", gin.WrapH(companyProxy))
+    routesFile := os.Getenv("ROUTES_CONFIG")
+    if routesFile == "" {
+        routesFile = "configs/routes.yaml"
+    }
+
+    cfg, err := config.LoadRoutes(routesFile)
+    if err != nil {
+        log.Fatal("Failed to load routes: ", err)
+    }
+
+    limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(time.Minute))
+
+    gateway, err := proxy.NewRouter(cfg, limiter)
+    if err != nil {
+        log.Fatal("Failed to build routes: ", err)
+    }
+    log.Printf("Loaded %d routes from %s", len(cfg.Routes), routesFile)
+
+    // Reload the routing table on SIGHUP, keeping the old one on error
+    go func() {
+        sighup := make(chan os.Signal, 1)
+        signal.Notify(sighup, syscall.SIGHUP)
+        for range sighup {
+            cfg, err := config.LoadRoutes(routesFile)
+            if err == nil {
+                err = gateway.Load(cfg)
+            }
+            if err != nil {
+                log.Printf("Route reload failed, keeping current routes: %v", err)
+                continue
+            }
+            log.Printf("Reloaded %d routes from %s", len(cfg.Routes), routesFile)
+        }
+    }()
+
+    // Validate tokens once here and forward identity downstream
+    router.Use(middleware.JWTAuthMiddleware(keys, gatewaySecret))
 
     // Health check
     router.GET("/health", func(c *gin.Context) {
         c.JSON(http.StatusOK, gin.H{"status": "healthy"})
     })
 
+    // Everything else goes through the routing table
+    router.NoRoute(gateway.Handler())
+
     port := os.Getenv("PORT")
     if port == "" {
         port = "8080"
@@ -47,4 +98,4 @@ func main() {
 
     log.Printf("API Gateway starting on port %s", port)
     router.Run(":" + port)
-}
\ No newline at end of file
+}
@@ -2,7 +2,12 @@ module jobfair-api-gateway
 
 go 1.23
 
-require github.com/gin-gonic/gin v1.9.1
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/golang-jwt/jwt/v5 v5.0.0
+	gopkg.in/yaml.v3 v3.0.1
+	jobfair-shared-libs v0.0.0
+)
 
 require (
 	github.com/bytedance/sonic v1.9.1 // indirect
@@ -28,5 +33,6 @@ require (
 	golang.org/x/sys v0.12.0 // indirect
 	golang.org/x/text v0.13.0 // indirect
 	google.golang.org/protobuf v1.30.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace jobfair-shared-libs => ../jobfair-shared-libs
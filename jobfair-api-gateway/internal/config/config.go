@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultRouteTimeout = 30 * time.Second
+
+// RouteConfig maps a path prefix to an upstream service.
+type RouteConfig struct {
+	Name         string        `yaml:"name"`
+	PathPrefix   string        `yaml:"path_prefix"`
+	Upstream     string        `yaml:"upstream"`
+	StripPrefix  bool          `yaml:"strip_prefix"`
+	AuthRequired bool          `yaml:"auth_required"`
+	Timeout      time.Duration `yaml:"timeout"`
+	// RateLimit names an entry in Config.RateLimits, empty means unlimited.
+	RateLimit string `yaml:"rate_limit"`
+}
+
+// RateLimitConfig is a token bucket policy: burst requests at once, refilled
+// at rate requests per period, counted separately for each key type.
+type RateLimitConfig struct {
+	Rate   int           `yaml:"rate"`
+	Period time.Duration `yaml:"period"`
+	Burst  int           `yaml:"burst"`
+	Keys   []string      `yaml:"keys"`
+}
+
+type Config struct {
+	RateLimits map[string]*RateLimitConfig `yaml:"rate_limits"`
+	Routes     []RouteConfig               `yaml:"routes"`
+}
+
+// LoadRoutes reads the routing table from a YAML file. ${VAR} references are
+// expanded from the environment so upstream URLs can stay in docker-compose.
+func LoadRoutes(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	if len(c.Routes) == 0 {
+		return errors.New("no routes defined")
+	}
+
+	for name, rl := range c.RateLimits {
+		if rl.Rate <= 0 || rl.Period <= 0 {
+			return fmt.Errorf("rate limit %q: rate and period must be positive", name)
+		}
+		if rl.Burst <= 0 {
+			rl.Burst = rl.Rate
+		}
+		if len(rl.Keys) == 0 {
+			rl.Keys = []string{"ip"}
+		}
+		for _, key := range rl.Keys {
+			if key != "ip" && key != "user" {
+				return fmt.Errorf("rate limit %q: unknown key %q (want ip or user)", name, key)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range c.Routes {
+		route := &c.Routes[i]
+
+		if !strings.HasPrefix(route.PathPrefix, "/") {
+			return fmt.Errorf("route %q: path_prefix must start with /", route.Name)
+		}
+		route.PathPrefix = strings.TrimRight(route.PathPrefix, "/")
+		if seen[route.PathPrefix] {
+			return fmt.Errorf("route %q: duplicate path_prefix %s", route.Name, route.PathPrefix)
+		}
+		seen[route.PathPrefix] = true
+
+		upstream, err := url.Parse(route.Upstream)
+		if err != nil || upstream.Scheme == "" || upstream.Host == "" {
+			return fmt.Errorf("route %q: invalid upstream %q", route.Name, route.Upstream)
+		}
+
+		if route.Timeout <= 0 {
+			route.Timeout = defaultRouteTimeout
+		}
+
+		if route.RateLimit != "" && c.RateLimits[route.RateLimit] == nil {
+			return fmt.Errorf("route %q: unknown rate_limit %q", route.Name, route.RateLimit)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jobfair-shared-libs/jwks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	HeaderUserID        = "X-User-ID"
+	HeaderUserType      = "X-User-Type"
+	HeaderSessionID     = "X-Session-ID"
+	HeaderGatewaySecret = "X-Gateway-Secret"
+)
+
+// Claims mirrors utils.Claims in the auth service.
+type Claims struct {
+	UserID    uint   `json:"user_id"`
+	UserType  string `json:"user_type"`
+	SessionID string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// StripIdentityHeaders removes identity headers supplied by the client so
+// downstream services only ever see values set by the gateway.
+func StripIdentityHeaders(r *http.Request) {
+	r.Header.Del(HeaderUserID)
+	r.Header.Del(HeaderUserType)
+	r.Header.Del(HeaderSessionID)
+	r.Header.Del(HeaderGatewaySecret)
+}
+
+// JWTAuthMiddleware validates the access token once at the gateway and
+// forwards the caller identity as X-User-ID / X-User-Type / X-Session-ID.
+// Requests without a token pass through untouched; requests with an invalid
+// token are rejected. Session revocation is checked downstream so the gateway
+// stays stateless.
+func JWTAuthMiddleware(keys *jwks.KeySet, gatewaySecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		StripIdentityHeaders(c.Request)
+
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+
+		// Format: Bearer <token>
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Invalid Authorization format"})
+			c.Abort()
+			return
+		}
+
+		claims, err := ParseToken(parts[1], keys)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		c.Request.Header.Set(HeaderUserID, strconv.FormatUint(uint64(claims.UserID), 10))
+		c.Request.Header.Set(HeaderUserType, claims.UserType)
+		if claims.SessionID != "" {
+			c.Request.Header.Set(HeaderSessionID, claims.SessionID)
+		}
+		if gatewaySecret != "" {
+			c.Request.Header.Set(HeaderGatewaySecret, gatewaySecret)
+		}
+
+		c.Set("user_id", claims.UserID)
+		c.Set("user_type", claims.UserType)
+		c.Next()
+	}
+}
+
+// ParseToken validates an RS256 access token issued by the auth service.
+func ParseToken(tokenString string, keys *jwks.KeySet) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys.KeyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.UserID == 0 {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,144 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"jobfair-api-gateway/internal/config"
+	"jobfair-api-gateway/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+type route struct {
+	config.RouteConfig
+	proxy     *httputil.ReverseProxy
+	rateLimit *ratelimit.Policy
+}
+
+// Router dispatches requests to upstreams using a routing table that can be
+// swapped at runtime without dropping in-flight requests.
+type Router struct {
+	routes  atomic.Pointer[[]*route]
+	limiter *ratelimit.Limiter
+}
+
+func NewRouter(cfg *config.Config, limiter *ratelimit.Limiter) (*Router, error) {
+	r := &Router{limiter: limiter}
+	if err := r.Load(cfg); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Load builds a new routing table and atomically replaces the current one.
+func (r *Router) Load(cfg *config.Config) error {
+	routes := make([]*route, 0, len(cfg.Routes))
+	for _, rc := range cfg.Routes {
+		target, err := url.Parse(rc.Upstream)
+		if err != nil {
+			return err
+		}
+
+		rt := &route{RouteConfig: rc, proxy: newReverseProxy(target, rc)}
+		if rl := cfg.RateLimits[rc.RateLimit]; rl != nil {
+			rt.rateLimit = &ratelimit.Policy{
+				Name:   rc.RateLimit,
+				Rate:   rl.Rate,
+				Period: rl.Period,
+				Burst:  rl.Burst,
+				Keys:   rl.Keys,
+			}
+		}
+		routes = append(routes, rt)
+	}
+
+	// Longest prefix first so /api/v1/jobs/apply beats /api/v1/jobs
+	sort.Slice(routes, func(i, j int) bool {
+		return len(routes[i].PathPrefix) > len(routes[j].PathPrefix)
+	})
+
+	r.routes.Store(&routes)
+	return nil
+}
+
+func (r *Router) match(path string) *route {
+	for _, rt := range *r.routes.Load() {
+		if path == rt.PathPrefix || strings.HasPrefix(path, rt.PathPrefix+"/") {
+			return rt
+		}
+	}
+	return nil
+}
+
+// Handler proxies the request to the matching upstream. It is meant to be
+// registered as the gin NoRoute handler so gateway-local routes win.
+func (r *Router) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rt := r.match(c.Request.URL.Path)
+		if rt == nil {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Route not found"})
+			return
+		}
+
+		if rt.AuthRequired {
+			if _, exists := c.Get("user_id"); !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Missing Authorization header"})
+				return
+			}
+		}
+
+		if rt.rateLimit != nil && !r.limiter.Allow(c, rt.rateLimit) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), rt.Timeout)
+		defer cancel()
+
+		rt.proxy.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+}
+
+func newReverseProxy(target *url.URL, rc config.RouteConfig) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		stripped := false
+		if rc.StripPrefix {
+			req.URL.Path = strings.TrimPrefix(req.URL.Path, rc.PathPrefix)
+			req.URL.RawPath = ""
+			stripped = req.URL.Path == ""
+		}
+		director(req)
+		// The default director joins an empty path as "/", which would
+		// trigger a trailing slash redirect upstream
+		if stripped && req.URL.Path != "/" {
+			req.URL.Path = strings.TrimSuffix(req.URL.Path, "/")
+		}
+		req.Host = target.Host
+	}
+
+	proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		status := http.StatusBadGateway
+		message := "Upstream service unavailable"
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+			message = "Upstream service timed out"
+		}
+
+		log.Printf("proxy error [%s] %s %s: %v", rc.Name, req.Method, req.URL.Path, err)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"success":false,"message":"` + message + `"}`))
+	}
+
+	return proxy
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps buckets in process memory. Idle buckets are swept
+// periodically so the map does not grow without bound.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+type memoryBucket struct {
+	bucketState
+	idleAfter time.Time
+}
+
+func NewMemoryStore(sweepInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{buckets: make(map[string]*memoryBucket)}
+	go s.sweep(sweepInterval)
+	return s
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy) (Result, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{}
+		s.buckets[key] = b
+	}
+
+	result := b.take(now, policy)
+	// A bucket that would be full again carries no state worth keeping
+	b.idleAfter = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+func (s *MemoryStore) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.mu.Lock()
+		for key, b := range s.buckets {
+			if now.After(b.idleAfter) {
+				delete(s.buckets, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limiter applies a policy to a request, taking one token from every bucket
+// the policy is keyed by. The request is rejected if any bucket is empty.
+type Limiter struct {
+	store Store
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store}
+}
+
+// Allow returns false after writing a 429 response when the request is over
+// the limit. Store errors fail open so an outage never blocks all traffic.
+func (l *Limiter) Allow(c *gin.Context, policy *Policy) bool {
+	var tightest *Result
+
+	for _, keyType := range policy.Keys {
+		key := requestKey(c, keyType)
+		if key == "" {
+			continue
+		}
+
+		result, err := l.store.Take(c.Request.Context(), policy.Name+":"+key, *policy)
+		if err != nil {
+			log.Printf("rate limit store error [%s]: %v", policy.Name, err)
+			continue
+		}
+
+		if tightest == nil || !result.Allowed || (tightest.Allowed && result.Remaining < tightest.Remaining) {
+			r := result
+			tightest = &r
+		}
+		if !result.Allowed {
+			break
+		}
+	}
+
+	if tightest == nil {
+		return true
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+	c.Header("RateLimit-Reset", seconds(tightest.ResetAfter))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Burst, int(policy.Period.Seconds())))
+
+	if !tightest.Allowed {
+		c.Header("Retry-After", seconds(tightest.RetryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{"success": false, "message": "Too many requests, please try again later"})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+func requestKey(c *gin.Context, keyType string) string {
+	switch keyType {
+	case "ip":
+		return "ip:" + c.ClientIP()
+	case "user":
+		if userID, exists := c.Get("user_id"); exists {
+			return fmt.Sprintf("user:%v", userID)
+		}
+	}
+	return ""
+}
+
+// seconds rounds up so clients never retry a moment too early.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Policy is a token bucket: Burst tokens at most, refilled at Rate tokens
+// per Period.
+type Policy struct {
+	Name   string
+	Rate   int
+	Period time.Duration
+	Burst  int
+	// Keys selects what the bucket is keyed by: "ip", "user" or both.
+	Keys []string
+}
+
+// refillInterval is how long it takes to earn a single token back.
+func (p Policy) refillInterval() time.Duration {
+	return p.Period / time.Duration(p.Rate)
+}
+
+// Result describes the bucket state after a Take.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is the time until the bucket is full again.
+	ResetAfter time.Duration
+	// RetryAfter is the time until the next token is available, zero when allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps bucket state. MemoryStore is enough for a single gateway
+// instance; a shared implementation (e.g. Redis) is needed when scaling out.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+// bucketState is the math shared by all Store implementations.
+type bucketState struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *bucketState) take(now time.Time, policy Policy) Result {
+	capacity := float64(policy.Burst)
+	perToken := policy.refillInterval()
+
+	if b.last.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)/float64(perToken))
+	}
+	b.last = now
+
+	result := Result{Limit: policy.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = time.Duration((capacity - b.tokens) * float64(perToken))
+	return result
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBucketStateTake(t *testing.T) {
+	// 6 requests per minute: one token every 10s, at most 3 saved up
+	policy := Policy{Rate: 6, Period: time.Minute, Burst: 3}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		after     time.Duration
+		allowed   bool
+		remaining int
+		retry     time.Duration
+		reset     time.Duration
+	}{
+		{"first request starts full", 0, true, 2, 0, 10 * time.Second},
+		{"second", 0, true, 1, 0, 20 * time.Second},
+		{"third empties the bucket", 0, true, 0, 0, 30 * time.Second},
+		{"rejected while empty", 0, false, 0, 10 * time.Second, 30 * time.Second},
+		{"half a token earned", 5 * time.Second, false, 0, 5 * time.Second, 25 * time.Second},
+		{"half a token left over", 10 * time.Second, true, 0, 0, 25 * time.Second},
+		{"refill is capped at burst", time.Hour, true, 2, 0, 10 * time.Second},
+	}
+
+	var b bucketState
+	now := start
+	for _, tt := range tests {
+		now = now.Add(tt.after)
+		got := b.take(now, policy)
+
+		if got.Allowed != tt.allowed {
+			t.Errorf("%s: allowed = %v, want %v", tt.name, got.Allowed, tt.allowed)
+		}
+		if got.Limit != policy.Burst {
+			t.Errorf("%s: limit = %d, want %d", tt.name, got.Limit, policy.Burst)
+		}
+		if got.Remaining != tt.remaining {
+			t.Errorf("%s: remaining = %d, want %d", tt.name, got.Remaining, tt.remaining)
+		}
+		if got.RetryAfter != tt.retry {
+			t.Errorf("%s: retry after = %v, want %v", tt.name, got.RetryAfter, tt.retry)
+		}
+		if got.ResetAfter != tt.reset {
+			t.Errorf("%s: reset after = %v, want %v", tt.name, got.ResetAfter, tt.reset)
+		}
+	}
+}
+
+func TestBucketStateIgnoresClockGoingBack(t *testing.T) {
+	policy := Policy{Rate: 1, Period: time.Minute, Burst: 1}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var b bucketState
+	if !b.take(now, policy).Allowed {
+		t.Fatal("first request rejected")
+	}
+	if b.take(now.Add(-time.Hour), policy).Allowed {
+		t.Fatal("request allowed after the clock went back")
+	}
+}
+
+func TestMemoryStoreSeparatesKeys(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	policy := Policy{Rate: 1, Period: time.Hour, Burst: 1}
+	ctx := context.Background()
+
+	for _, key := range []string{"ip:10.0.0.1", "ip:10.0.0.2"} {
+		result, err := store.Take(ctx, key, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Allowed {
+			t.Errorf("%s: first request rejected", key)
+		}
+	}
+
+	result, err := store.Take(ctx, "ip:10.0.0.1", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Allowed {
+		t.Error("second request of an empty bucket allowed")
+	}
+}
@@ -2,15 +2,21 @@
 package main
 
 import (
+	"errors"
 	"log"
 	"os"
+	"time"
 
 	"jobfair-auth-service/internal/config"
 	"jobfair-auth-service/internal/handlers"
 	"jobfair-auth-service/internal/middleware"
+	"jobfair-auth-service/internal/models"
 	"jobfair-auth-service/internal/repository"
 	"jobfair-auth-service/internal/services"
+	"jobfair-auth-service/internal/utils"
 	"jobfair-auth-service/pkg/database"
+	"jobfair-shared-libs/notification"
+	"jobfair-shared-libs/storage"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +24,15 @@ import (
 func main() {
 	cfg := config.Load()
 
+	if cfg.UsesDefaultJWTSecret() && !cfg.IsDevelopment() {
+		log.Fatal("JWT_SECRET must be set outside development")
+	}
+
+	signingKeys, err := loadSigningKeys(cfg)
+	if err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+
 	db, err := database.Connect(cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
@@ -33,31 +48,177 @@ func main() {
 	profileRepo := repository.NewJobSeekerProfileRepository(db)
 	companyProfileRepo := repository.NewCompanyBasicProfileRepository(db)
 	otpRepo := repository.NewOTPRepository(db)
+	emailVerificationRepo := repository.NewEmailVerificationRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	adminAuditLogRepo := repository.NewAdminAuditLogRepository(db)
+	resumeRepo := repository.NewResumeRepository(db)
+
+	// Bersihkan OTP kadaluarsa secara berkala (disimpan 24 jam untuk daily cap)
+	if cfg.OTPCleanupInterval > 0 {
+		go startOTPCleanup(otpRepo, cfg.OTPCleanupInterval)
+	}
+	// Refresh token kadaluarsa juga dibersihkan (disimpan 24 jam untuk deteksi reuse)
+	if cfg.RefreshTokenCleanupInterval > 0 {
+		go startRefreshTokenCleanup(refreshTokenRepo, cfg.RefreshTokenCleanupInterval)
+	}
+
+	// OTP delivery (console in development, SMS or WhatsApp otherwise)
+	otpSender, err := notification.NewOTPSender(notification.OTPSenderConfig{
+		Provider:                 cfg.OTPProvider,
+		Development:              cfg.IsDevelopment(),
+		SMSGatewayURL:            cfg.SMSGatewayURL,
+		SMSGatewayAPIKey:         cfg.SMSGatewayAPIKey,
+		SMSSenderID:              cfg.SMSSenderID,
+		WhatsAppAPIURL:           cfg.WhatsAppAPIURL,
+		WhatsAppPhoneNumberID:    cfg.WhatsAppPhoneNumberID,
+		WhatsAppAccessToken:      cfg.WhatsAppAccessToken,
+		WhatsAppTemplateName:     cfg.WhatsAppTemplateName,
+		WhatsAppTemplateLanguage: cfg.WhatsAppTemplateLanguage,
+	})
+	if err != nil {
+		log.Fatal("Failed to configure OTP sender:", err)
+	}
+
+	// Email delivery (console or file outbox in development, SMTP otherwise).
+	// Verification and reset links are credentials, they must not be logged
+	if !cfg.IsDevelopment() && cfg.MailProvider != "smtp" {
+		log.Fatal("MAIL_PROVIDER must be smtp outside development, the console and file providers expose email links")
+	}
+	mailer, err := notification.NewMailer(notification.MailerConfig{
+		Provider:     cfg.MailProvider,
+		From:         cfg.MailFrom,
+		SMTPHost:     cfg.SMTPHost,
+		SMTPPort:     cfg.SMTPPort,
+		SMTPUsername: cfg.SMTPUsername,
+		SMTPPassword: cfg.SMTPPassword,
+		OutboxDir:    cfg.MailOutboxDir,
+	})
+	if err != nil {
+		log.Fatal("Failed to configure mailer:", err)
+	}
+
+	// Profile photo and resume storage (local disk or S3 compatible)
+	store, err := storage.New(storage.Config{
+		Provider:          cfg.StorageProvider,
+		LocalDir:          cfg.StorageLocalDir,
+		S3Endpoint:        cfg.S3Endpoint,
+		S3Region:          cfg.S3Region,
+		S3Bucket:          cfg.S3Bucket,
+		S3AccessKeyID:     cfg.S3AccessKeyID,
+		S3SecretAccessKey: cfg.S3SecretAccessKey,
+		S3ForcePathStyle:  cfg.S3ForcePathStyle,
+		S3PublicEndpoint:  cfg.S3PublicEndpoint,
+	})
+	if err != nil {
+		log.Fatal("Failed to configure file storage:", err)
+	}
+
+	if cfg.OTPTestMode {
+		if cfg.OTPTestCode == "" || len(cfg.OTPTestPhoneNumbers) == 0 {
+			log.Fatal("OTP_TEST_MODE requires OTP_TEST_CODE and OTP_TEST_PHONE_NUMBERS")
+		}
+		log.Printf("⚠️  OTP test mode enabled for %d phone number(s)", len(cfg.OTPTestPhoneNumbers))
+	}
 
 	// Initialize services
+	tokenService := services.NewTokenService(userRepo, refreshTokenRepo, signingKeys)
+	emailVerificationService := services.NewEmailVerificationService(
+		userRepo,
+		emailVerificationRepo,
+		mailer,
+		services.EmailVerificationSettings{
+			Secret:         cfg.JWTSecret,
+			TTL:            cfg.EmailVerificationTTL,
+			VerifyURL:      cfg.EmailVerificationURL,
+			ResendCooldown: cfg.EmailVerificationCooldown,
+		},
+	)
+	otpSettings := services.OTPSettings{
+		HashSecret:     cfg.OTPHashSecret,
+		MaxAttempts:    cfg.OTPMaxAttempts,
+		ResendCooldown: cfg.OTPResendCooldown,
+		DailyLimit:     cfg.OTPDailyLimit,
+	}
 	registrationService := services.NewRegistrationService(
 		userRepo,
 		profileRepo,
 		companyProfileRepo,
 		otpRepo,
-		cfg.JWTSecret,
+		otpSender,
+		emailVerificationService,
+		tokenService,
+		cfg.IsDevelopment(),
+		services.OTPTestMode{
+			Enabled:      cfg.OTPTestMode,
+			Code:         cfg.OTPTestCode,
+			PhoneNumbers: cfg.OTPTestPhoneNumbers,
+		},
+		otpSettings,
+		store,
+	)
+	passwordResetService := services.NewPasswordResetService(
+		userRepo,
+		passwordResetRepo,
+		otpRepo,
+		mailer,
+		otpSender,
+		tokenService,
+		services.PasswordResetSettings{
+			Secret:         cfg.JWTSecret,
+			TTL:            cfg.PasswordResetTTL,
+			ResetURL:       cfg.PasswordResetURL,
+			ResendCooldown: cfg.PasswordResetCooldown,
+		},
+		otpSettings,
 	)
-	authService := services.NewAuthService(userRepo, cfg.JWTSecret)
+	sessionService := services.NewSessionService(refreshTokenRepo)
+	adminService := services.NewAdminService(userRepo, adminAuditLogRepo, tokenService)
+	authService := services.NewAuthService(userRepo, tokenService, cfg.RequireEmailVerification)
+	resumeService := services.NewResumeService(resumeRepo, store, services.ResumeSettings{
+		Secret:      cfg.JWTSecret,
+		DownloadURL: cfg.ResumeDownloadURL,
+		LinkTTL:     cfg.ResumeLinkTTL,
+	})
 
 	// Initialize handlers
 	registrationHandler := handlers.NewRegistrationHandler(registrationService)
 	authHandler := handlers.NewAuthHandler(authService)
+	emailVerificationHandler := handlers.NewEmailVerificationHandler(emailVerificationService)
+	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	adminHandler := handlers.NewAdminHandler(adminService)
+	jwksHandler := handlers.NewJWKSHandler(signingKeys)
+	fileHandler := handlers.NewFileHandler(store, "users/", cfg.FileURLTTL)
+	resumeHandler := handlers.NewResumeHandler(resumeService)
 
 	// Initialize middleware
-	authMiddleware := middleware.JWTAuthMiddleware(cfg.JWTSecret)
+	sessionCache := middleware.NewSessionCache(refreshTokenRepo.IsFamilyActive, cfg.SessionCacheTTL)
+	authMiddleware := middleware.JWTAuthMiddleware(signingKeys, sessionCache)
+
+	// Brute-force protection for credential and OTP endpoints, every route has
+	// its own buckets so one flow cannot lock a client out of another
+	loginLimiter := middleware.RateLimitMiddleware(5, time.Minute)
+	sendOTPLimiter := middleware.RateLimitMiddleware(3, 10*time.Minute)
+	verifyOTPLimiter := middleware.RateLimitMiddleware(5, 10*time.Minute)
+	verifyEmailLimiter := middleware.RateLimitMiddleware(10, 10*time.Minute)
+	resendEmailLimiter := middleware.RateLimitMiddleware(3, 10*time.Minute)
+	forgotPasswordLimiter := middleware.RateLimitMiddleware(3, 10*time.Minute)
+	resetPasswordLimiter := middleware.RateLimitMiddleware(5, 10*time.Minute)
 
 	router := gin.Default()
 
+	// Only trust X-Forwarded-For from known proxies (the API gateway), otherwise
+	// clients could pick their own rate limit key
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
+
 	// CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Refresh-Token, X-Device-Name")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -75,6 +236,9 @@ func main() {
 		})
 	})
 
+	// Public keys for access token verification
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	api := router.Group("/api/v1")
 	{
 		// Registration flow (multi-step) - UNIFIED for both job_seeker and company
@@ -82,8 +246,8 @@ func main() {
 		{
 			register.POST("/init", registrationHandler.RegisterStep1)                         // Email & Password + UserType
 			register.PUT("/profile", authMiddleware, registrationHandler.CompleteBasicProfile) // Unified: detects job_seeker or company
-			register.POST("/send-otp", authMiddleware, registrationHandler.SendPhoneOTP)       // Send OTP
-			register.POST("/verify-otp", registrationHandler.VerifyPhoneOTP)                   // Verify OTP
+			register.POST("/send-otp", sendOTPLimiter, authMiddleware, registrationHandler.SendPhoneOTP) // Send OTP
+			register.POST("/verify-otp", verifyOTPLimiter, registrationHandler.VerifyPhoneOTP)           // Verify OTP
 
 			// Job seeker specific steps
 			register.POST("/employment", authMiddleware, registrationHandler.SetEmploymentStatus) // Job seeker only
@@ -92,14 +256,65 @@ func main() {
 
 			// Unified photo/logo upload
 			register.POST("/photo", authMiddleware, registrationHandler.UploadProfilePhoto) // Profile photo / Company logo
+		}
 
-			// Debug endpoint
-			register.GET("/users", authHandler.GetAllUsers)
+		// Stored profile photos and company logos
+		api.GET("/files/*key", fileHandler.ServeFile)
+
+		// Resume library (job seeker only), files are only reachable through
+		// time-limited download links
+		resumes := api.Group("/resumes", authMiddleware, middleware.RequireUserType(string(models.UserTypeJobSeeker)))
+		{
+			resumes.GET("", resumeHandler.ListResumes)
+			resumes.POST("", resumeHandler.UploadResume)
+			resumes.PUT("/:id", resumeHandler.UpdateResume)
+			resumes.DELETE("/:id", resumeHandler.DeleteResume)
+			resumes.GET("/:id/download-link", resumeHandler.GetDownloadLink)
+		}
+		api.GET("/resume-downloads", resumeHandler.Download)
+
+		// Email verification
+		api.GET("/verify-email", verifyEmailLimiter, emailVerificationHandler.VerifyEmail)
+		api.POST("/verify-email/resend", resendEmailLimiter, authMiddleware, emailVerificationHandler.ResendVerificationEmail)
+
+		// Password reset (emailed link or phone OTP)
+		password := api.Group("/password")
+		{
+			password.POST("/forgot", forgotPasswordLimiter, passwordResetHandler.ForgotPassword)
+			password.POST("/reset", resetPasswordLimiter, passwordResetHandler.ResetPassword)
 		}
 
 		// Authentication
-		api.POST("/login", authHandler.Login)
+		api.POST("/login", loginLimiter, authHandler.Login)
 		api.POST("/refresh", authHandler.RefreshToken)
+		api.POST("/logout", authMiddleware, authHandler.Logout)
+		api.POST("/logout-all", authMiddleware, authHandler.LogoutAll)
+
+		// Session / device management
+		api.GET("/sessions", authMiddleware, sessionHandler.ListSessions)
+		api.DELETE("/sessions/:id", authMiddleware, sessionHandler.RevokeSession)
+
+		// Admin moderation, every action is written to admin_audit_logs
+		admin := api.Group("/admin", authMiddleware, middleware.RequireUserType(string(models.UserTypeAdmin)))
+		{
+			admin.GET("/users", adminHandler.ListUsers)
+			admin.GET("/users/:id", adminHandler.GetUser)
+			admin.POST("/users/:id/deactivate", adminHandler.DeactivateUser)
+			admin.POST("/users/:id/reactivate", adminHandler.ReactivateUser)
+			admin.GET("/audit-logs/users", adminHandler.ListAuditLogs)
+		}
+	}
+
+	// Service-to-service endpoints, not exposed through the gateway
+	if cfg.InternalAPISecret != "" {
+		internal := router.Group("/internal", middleware.InternalAuthMiddleware(cfg.InternalAPISecret))
+		internal.GET("/sessions/:id", sessionHandler.SessionStatus)
+		internal.GET("/users/:id/resume", resumeHandler.ResolveResume)
+		internal.POST("/users/:id/resumes", resumeHandler.UploadApplicationResume)
+		internal.DELETE("/users/:id/resumes/:resume_id", resumeHandler.DiscardApplicationResume)
+		internal.POST("/resumes/:id/download-link", resumeHandler.ApplicationDownloadLink)
+	} else {
+		log.Println("⚠️  INTERNAL_API_SECRET not set, session status and resume endpoints disabled")
 	}
 
 	port := os.Getenv("PORT")
@@ -112,3 +327,56 @@ func main() {
 	log.Printf("🔑 API endpoint: http://localhost:%s/api/v1", port)
 	router.Run(":" + port)
 }
+
+// loadSigningKeys reads the RSA keys from JWT_KEYS_DIR. Without it an
+// ephemeral key is generated, which is only acceptable in development since
+// every restart invalidates issued access tokens.
+func loadSigningKeys(cfg *config.Config) (*utils.KeySet, error) {
+	if cfg.JWTKeysDir != "" {
+		keys, err := utils.LoadKeySet(cfg.JWTKeysDir, cfg.JWTActiveKeyID)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("🔐 Signing access tokens with key %s (%d key(s) published)", keys.Active().ID, len(keys.JWKS()))
+		return keys, nil
+	}
+
+	if !cfg.IsDevelopment() {
+		return nil, errors.New("JWT_KEYS_DIR is required outside development")
+	}
+
+	log.Println("⚠️  JWT_KEYS_DIR not set, using an ephemeral signing key (development only)")
+	return utils.GenerateKeySet()
+}
+
+func startRefreshTokenCleanup(refreshTokenRepo *repository.RefreshTokenRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := refreshTokenRepo.DeleteExpired(24 * time.Hour)
+		if err != nil {
+			log.Printf("Refresh token cleanup failed: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Refresh token cleanup removed %d expired token(s)", deleted)
+		}
+	}
+}
+
+func startOTPCleanup(otpRepo *repository.OTPRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := otpRepo.DeleteExpiredOTPs(24 * time.Hour)
+		if err != nil {
+			log.Printf("OTP cleanup failed: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("OTP cleanup removed %d expired OTP(s)", deleted)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminService *services.AdminService
+}
+
+func NewAdminHandler(adminService *services.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// ListUsers supports ?q= (email or name), user_type, is_active, page and limit
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	page, limit, offset := pagination(c)
+
+	filter := models.UserFilter{
+		Query:    c.Query("q"),
+		UserType: models.UserType(c.Query("user_type")),
+	}
+	if isActive, err := strconv.ParseBool(c.Query("is_active")); err == nil {
+		filter.IsActive = &isActive
+	}
+
+	users, total, err := h.adminService.ListUsers(filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Data:    models.UserListData{Users: users, Total: total, Page: page, Limit: limit},
+		Message: "Users retrieved",
+		Success: true,
+	})
+}
+
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.GetUser(userID)
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: user, Message: "User retrieved", Success: true})
+}
+
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.DeactivateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	user, err := h.adminService.DeactivateUser(c.GetUint("user_id"), userID, req.Reason, c.ClientIP())
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: user, Message: "User deactivated", Success: true})
+}
+
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	// The reason is optional when reactivating
+	var req models.ReactivateUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+			return
+		}
+	}
+
+	user, err := h.adminService.ReactivateUser(c.GetUint("user_id"), userID, req.Reason, c.ClientIP())
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: user, Message: "User reactivated", Success: true})
+}
+
+// ListAuditLogs supports admin_id, target_type, target_id, action, page and limit
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	page, limit, offset := pagination(c)
+
+	filter := models.AuditLogFilter{
+		TargetType: c.Query("target_type"),
+		Action:     c.Query("action"),
+	}
+	if adminID, err := strconv.ParseUint(c.Query("admin_id"), 10, 32); err == nil {
+		filter.AdminID = uint(adminID)
+	}
+	if targetID, err := strconv.ParseUint(c.Query("target_id"), 10, 32); err == nil {
+		filter.TargetID = uint(targetID)
+	}
+
+	logs, total, err := h.adminService.ListAuditLogs(filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Data:    models.AuditLogListData{Logs: logs, Total: total, Page: page, Limit: limit},
+		Message: "Audit logs retrieved",
+		Success: true,
+	})
+}
+
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid user ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// pagination reads page and limit (default 1 and 20, limit capped at 100)
+func pagination(c *gin.Context) (int, int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit, (page - 1) * limit
+}
+
+func adminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Message: err.Error()})
+	case errors.Is(err, services.ErrCannotModerateSelf):
+		c.JSON(http.StatusForbidden, models.APIResponse{Success: false, Message: err.Error()})
+	case errors.Is(err, services.ErrUserAlreadyActive), errors.Is(err, services.ErrUserAlreadyBlocked):
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+	}
+}
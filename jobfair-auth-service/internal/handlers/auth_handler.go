@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"jobfair-auth-service/internal/models"
@@ -61,7 +62,23 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(&req)
+	response, err := h.authService.Login(&req, clientInfo(c))
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   err.Error(),
+			"code":    "email_not_verified",
+		})
+		return
+	}
+	if errors.Is(err, services.ErrAccountDeactivated) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   err.Error(),
+			"code":    "account_deactivated",
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"success": false,
@@ -75,10 +92,11 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			"token":         response.Token,
 			"refresh_token": response.RefreshToken,
 			"user": gin.H{
-				"id":        response.User.ID,
-				"email":     response.User.Email,
-				"user_type": response.User.UserType,
-				"is_active": response.User.IsActive,
+				"id":                response.User.ID,
+				"email":             response.User.Email,
+				"user_type":         response.User.UserType,
+				"is_active":         response.User.IsActive,
+				"is_email_verified": response.User.IsEmailVerified,
 			},
 		},
 		"message": "Login successful",
@@ -96,7 +114,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authService.RefreshToken(refreshToken)
+	tokens, err := h.authService.RefreshToken(refreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"status": false,
@@ -107,18 +125,55 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": gin.H{
-			"token": token,
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
 		},
 		"message": "Token refreshed successfully",
 		"status":  true,
 	})
 }
 
-func (h *AuthHandler) GetAllUsers(c *gin.Context) {
-	users, err := h.authService.GetAllUsers()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+// Logout revokes the session of the refresh token sent in the Refresh-Token header
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	refreshToken := c.GetHeader("Refresh-Token")
+	if refreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  "Refresh token required in header",
+		})
 		return
 	}
-	c.JSON(http.StatusOK, users)
+
+	if err := h.authService.Logout(userID, refreshToken); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+		"status":  true,
+	})
+}
+
+// LogoutAll revokes every session of the current user
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	if err := h.authService.LogoutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out from all devices",
+		"status":  true,
+	})
 }
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"jobfair-auth-service/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clientInfo collects the device details stored with a refresh token.
+// Mobile apps send X-Device-Name, browsers are identified by user agent.
+func clientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: c.GetHeader("X-Device-Name"),
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationService *services.EmailVerificationService
+}
+
+func NewEmailVerificationHandler(emailVerificationService *services.EmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{emailVerificationService: emailVerificationService}
+}
+
+// VerifyEmail confirms the link sent by email: GET /verify-email?token=...
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "token is required"})
+		return
+	}
+
+	data, err := h.emailVerificationService.VerifyEmail(token)
+	if errors.Is(err, services.ErrEmailAlreadyVerified) {
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: data, Message: "Email verified successfully", Success: true})
+}
+
+func (h *EmailVerificationHandler) ResendVerificationEmail(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	data, err := h.emailVerificationService.ResendVerification(userID)
+	if errors.Is(err, services.ErrEmailVerificationCooldown) {
+		c.JSON(http.StatusTooManyRequests, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrEmailAlreadyVerified) {
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: data, Message: "Verification email sent", Success: true})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-shared-libs/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FileHandler serves stored profile photos. Backends that can sign URLs get
+// a redirect to a short-lived direct URL, otherwise the file is streamed.
+type FileHandler struct {
+	store storage.Storage
+	// prefix limits the keys served, other services store under their own
+	prefix string
+	urlTTL time.Duration
+}
+
+func NewFileHandler(store storage.Storage, prefix string, urlTTL time.Duration) *FileHandler {
+	return &FileHandler{
+		store:  store,
+		prefix: prefix,
+		urlTTL: urlTTL,
+	}
+}
+
+func (h *FileHandler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if !storage.ValidKey(key) || !strings.HasPrefix(key, h.prefix) {
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Message: "File not found"})
+		return
+	}
+
+	if signer, ok := h.store.(storage.URLSigner); ok {
+		url, err := signer.SignedURL(c.Request.Context(), key, h.urlTTL)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: "Failed to sign file URL"})
+			return
+		}
+		// Cached a little shorter than the signature is valid
+		c.Header("Cache-Control", "private, max-age="+strconv.Itoa(int(h.urlTTL.Seconds())/2))
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	reader, object, err := h.store.Get(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Message: "File not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: "Failed to read file"})
+		return
+	}
+	defer reader.Close()
+
+	// Keys are content addressed, the bytes behind a key never change
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		if object.ContentType != "" {
+			c.Header("Content-Type", object.ContentType)
+		}
+		http.ServeContent(c.Writer, c.Request, key, object.ModTime, seeker)
+		return
+	}
+
+	contentType := object.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, object.Size, contentType, reader, nil)
+}
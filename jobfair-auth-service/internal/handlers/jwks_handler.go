@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	"jobfair-auth-service/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	keys *utils.KeySet
+}
+
+func NewJWKSHandler(keys *utils.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// GetJWKS publishes the public keys other services use to verify access
+// tokens. Verifiers cache it and refetch when they see an unknown kid.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": h.keys.JWKS()})
+}
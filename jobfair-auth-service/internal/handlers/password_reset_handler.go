@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetHandler struct {
+	passwordResetService *services.PasswordResetService
+}
+
+func NewPasswordResetHandler(passwordResetService *services.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{passwordResetService: passwordResetService}
+}
+
+// ForgotPassword always answers with the same message so callers cannot
+// probe which emails or phone numbers are registered.
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	if err := h.passwordResetService.RequestReset(&req, c.ClientIP()); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Message: "If an account matches, password reset instructions have been sent",
+		Success: true,
+	})
+}
+
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Message: "Password has been reset, please log in again", Success: true})
+}
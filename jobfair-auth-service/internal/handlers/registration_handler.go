@@ -2,9 +2,8 @@
 package handlers
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
-	"time"
 
 	"jobfair-auth-service/internal/models"
 	"jobfair-auth-service/internal/services"
@@ -27,7 +26,7 @@ func (h *RegistrationHandler) RegisterStep1(c *gin.Context) {
 		return
 	}
 
-	data, err := h.registrationService.RegisterStep1(&req)
+	data, err := h.registrationService.RegisterStep1(&req, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
 		return
@@ -88,6 +87,10 @@ func (h *RegistrationHandler) SendPhoneOTP(c *gin.Context) {
 	}
 
 	data, err := h.registrationService.SendPhoneOTP(userID, &req)
+	if errors.Is(err, services.ErrOTPResendCooldown) || errors.Is(err, services.ErrOTPDailyLimit) {
+		c.JSON(http.StatusTooManyRequests, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
 		return
@@ -176,26 +179,14 @@ func (h *RegistrationHandler) UploadProfilePhoto(c *gin.Context) {
 		return
 	}
 
-	if file.Size > 5*1024*1024 {
-		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "file size too large (max 5MB)"})
-		return
-	}
-
-	// Get user to determine file naming
+	// Get user to pick the response message
 	user, err := h.registrationService.GetUserByID(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "User not found"})
 		return
 	}
 
-	var photoURL string
-	if user.UserType == models.UserTypeCompany {
-		photoURL = fmt.Sprintf("/uploads/companies/logos/%d_%d.jpg", userID, time.Now().Unix())
-	} else {
-		photoURL = fmt.Sprintf("/uploads/profiles/%d_%d.jpg", userID, time.Now().Unix())
-	}
-
-	data, err := h.registrationService.UploadProfilePhoto(userID, photoURL)
+	data, err := h.registrationService.UploadProfilePhoto(userID, file)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
 		return
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResumeHandler struct {
+	resumeService *services.ResumeService
+}
+
+func NewResumeHandler(resumeService *services.ResumeService) *ResumeHandler {
+	return &ResumeHandler{resumeService: resumeService}
+}
+
+func (h *ResumeHandler) ListResumes(c *gin.Context) {
+	resumes, err := h.resumeService.ListResumes(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: resumes, Message: "Resumes retrieved", Success: true})
+}
+
+func (h *ResumeHandler) UploadResume(c *gin.Context) {
+	var req models.UploadResumeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "File is required"})
+		return
+	}
+
+	resume, err := h.resumeService.UploadResume(c.GetUint("user_id"), file, &req)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{Data: resume, Message: "Resume uploaded", Success: true})
+}
+
+func (h *ResumeHandler) UpdateResume(c *gin.Context) {
+	resumeID, ok := resumeIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateResumeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	resume, err := h.resumeService.UpdateResume(c.GetUint("user_id"), resumeID, &req)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: resume, Message: "Resume updated", Success: true})
+}
+
+func (h *ResumeHandler) DeleteResume(c *gin.Context) {
+	resumeID, ok := resumeIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.resumeService.DeleteResume(c.GetUint("user_id"), resumeID); err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Message: "Resume deleted", Success: true})
+}
+
+func (h *ResumeHandler) GetDownloadLink(c *gin.Context) {
+	resumeID, ok := resumeIDParam(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.resumeService.DownloadLink(c.GetUint("user_id"), resumeID)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: link, Message: "Download link created", Success: true})
+}
+
+// Download serves the file behind a download link. The token is the only
+// credential, so this route is public.
+func (h *ResumeHandler) Download(c *gin.Context) {
+	resume, signedURL, reader, err := h.resumeService.OpenDownload(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	if signedURL != "" {
+		c.Redirect(http.StatusFound, signedURL)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, resume.FileSize, resume.MimeType, reader, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": resume.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// ResolveResume is called by the company service when a job seeker applies,
+// resume_id 0 or missing picks the default resume
+func (h *ResumeHandler) ResolveResume(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid user ID"})
+		return
+	}
+	resumeID, err := strconv.ParseUint(c.DefaultQuery("resume_id", "0"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid resume ID"})
+		return
+	}
+
+	resume, err := h.resumeService.ResolveResume(uint(userID), uint(resumeID))
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: resume, Message: "Resume retrieved", Success: true})
+}
+
+// UploadApplicationResume is called by the company service for a resume file
+// uploaded with an application
+func (h *ResumeHandler) UploadApplicationResume(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid user ID"})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "File is required"})
+		return
+	}
+
+	resume, err := h.resumeService.UploadApplicationResume(uint(userID), file)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{Data: resume, Message: "Resume uploaded", Success: true})
+}
+
+// DiscardApplicationResume is called by the company service when an
+// application with an uploaded resume could not be saved
+func (h *ResumeHandler) DiscardApplicationResume(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid user ID"})
+		return
+	}
+	resumeID, err := strconv.ParseUint(c.Param("resume_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid resume ID"})
+		return
+	}
+
+	if err := h.resumeService.DiscardApplicationResume(c.Request.Context(), uint(userID), uint(resumeID)); err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Message: "Resume deleted", Success: true})
+}
+
+// ApplicationDownloadLink is called by the company service for the resume of
+// an application to one of the company's jobs
+func (h *ResumeHandler) ApplicationDownloadLink(c *gin.Context) {
+	resumeID, ok := resumeIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.ResumeDownloadLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	link, err := h.resumeService.ApplicationDownloadLink(req.UserID, resumeID)
+	if err != nil {
+		resumeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: link, Message: "Download link created", Success: true})
+}
+
+func resumeIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Message: "Invalid resume ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func resumeError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrResumeNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidDownloadLink):
+		status = http.StatusUnauthorized
+	case errors.Is(err, services.ErrResumeLimitReached):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidResumeFile),
+		errors.Is(err, services.ErrResumeTooLarge),
+		errors.Is(err, services.ErrResumeTypeMismatch):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, models.APIResponse{Success: false, Message: err.Error()})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	sessions, err := h.sessionService.ListSessions(userID, c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: sessions, Message: "Sessions retrieved", Success: true})
+}
+
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	err := h.sessionService.RevokeSession(userID, c.Param("id"))
+	if errors.Is(err, services.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Message: "Session revoked", Success: true})
+}
+
+// SessionStatus is called by other services to check whether the session of
+// an access token is still active
+func (h *SessionHandler) SessionStatus(c *gin.Context) {
+	active, err := h.sessionService.IsSessionActive(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{Data: gin.H{"active": active}, Message: "Session status", Success: true})
+}
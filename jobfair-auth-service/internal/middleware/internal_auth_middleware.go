@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalAuthMiddleware melindungi endpoint service-to-service dengan shared
+// secret di header X-Internal-Secret
+func InternalAuthMiddleware(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader("X-Internal-Secret")
+		if provided == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid internal secret"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -1,15 +1,20 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
 	"strings"
 
+	"jobfair-auth-service/internal/utils"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWTAuthMiddleware memverifikasi token JWT dan menyimpan user_id ke context
-func JWTAuthMiddleware(secret string) gin.HandlerFunc {
+// JWTAuthMiddleware memverifikasi token JWT dan menyimpan user_id dan user_type ke context.
+// Token dari sesi yang sudah di-revoke (logout, revoke sesi, user dinonaktifkan)
+// ditolak walaupun belum kadaluarsa.
+func JWTAuthMiddleware(keys *utils.KeySet, sessions *SessionCache) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 
@@ -30,14 +35,8 @@ func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		// Parse token menggunakan secret
-		token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-			// Validasi metode signing
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(secret), nil
-		})
+		// Parse token dengan public key sesuai header kid (RS256)
+		token, err := jwt.Parse(tokenString, keys.KeyFunc)
 
 		if err != nil || !token.Valid {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
@@ -56,6 +55,28 @@ func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 
 			// Simpan user_id di context
 			c.Set("user_id", uint(userID))
+
+			// user_type dipakai RequireUserType
+			if userType, ok := claims["user_type"].(string); ok {
+				c.Set("user_type", userType)
+			}
+
+			// sid = sesi (refresh token family) tempat token ini diterbitkan
+			if sessionID, ok := claims["sid"].(string); ok {
+				active, err := sessions.IsActive(sessionID)
+				if err != nil {
+					log.Printf("Session check failed for %s: %v", sessionID, err)
+					c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify session"})
+					c.Abort()
+					return
+				}
+				if !active {
+					c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+					c.Abort()
+					return
+				}
+				c.Set("session_id", sessionID)
+			}
 			c.Next()
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
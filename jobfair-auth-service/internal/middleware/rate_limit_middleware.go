@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimitMiddleware membatasi request per IP dengan token bucket in-memory.
+// Ini lapisan kedua di belakang rate limit API gateway, untuk request yang
+// langsung masuk ke auth service.
+func RateLimitMiddleware(limit int, period time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	buckets := make(map[string]*bucket)
+	perToken := period / time.Duration(limit)
+
+	// Bersihkan bucket yang sudah penuh kembali agar map tidak terus membesar
+	go func() {
+		for now := range time.Tick(period) {
+			mu.Lock()
+			for key, b := range buckets {
+				if now.Sub(b.last) > period {
+					delete(buckets, key)
+				}
+			}
+			mu.Unlock()
+		}
+	}()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		key := c.ClientIP()
+
+		mu.Lock()
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{tokens: float64(limit), last: now}
+			buckets[key] = b
+		}
+		b.tokens = math.Min(float64(limit), b.tokens+float64(now.Sub(b.last))/float64(perToken))
+		b.last = now
+
+		allowed := b.tokens >= 1
+		if allowed {
+			b.tokens--
+		}
+		remaining := int(b.tokens)
+		resetAfter := time.Duration((float64(limit) - b.tokens) * float64(perToken))
+		retryAfter := time.Duration((1 - b.tokens) * float64(perToken))
+		mu.Unlock()
+
+		c.Header("RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(int(math.Ceil(resetAfter.Seconds()))))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"success": false, "error": "Too many requests, please try again later"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireUserType membatasi endpoint untuk user_type tertentu dari token.
+// Harus dipasang setelah JWTAuthMiddleware.
+func RequireUserType(userTypes ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(userTypes))
+	for _, userType := range userTypes {
+		allowed[userType] = true
+	}
+
+	return func(c *gin.Context) {
+		if !allowed[c.GetString("user_type")] {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
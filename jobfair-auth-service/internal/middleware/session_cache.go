@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+type sessionEntry struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// SessionCache menyimpan sebentar apakah sesi (klaim sid) dari access token
+// masih aktif, supaya token dari sesi yang di-revoke atau user yang
+// dinonaktifkan ditolak tanpa query database di setiap request.
+type SessionCache struct {
+	lookup func(sessionID string) (bool, error)
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]sessionEntry
+}
+
+func NewSessionCache(lookup func(sessionID string) (bool, error), ttl time.Duration) *SessionCache {
+	return &SessionCache{
+		lookup:  lookup,
+		ttl:     ttl,
+		entries: make(map[string]sessionEntry),
+	}
+}
+
+// IsActive mengembalikan status sesi, error berarti status tidak bisa dipastikan
+func (s *SessionCache) IsActive(sessionID string) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	if entry, ok := s.entries[sessionID]; ok && now.Before(entry.expiresAt) {
+		s.mu.Unlock()
+		return entry.active, nil
+	}
+	s.mu.Unlock()
+
+	active, err := s.lookup(sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	// Buang entri kadaluarsa supaya cache tidak terus membesar
+	for id, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, id)
+		}
+	}
+	s.entries[sessionID] = sessionEntry{active: active, expiresAt: now.Add(s.ttl)}
+	s.mu.Unlock()
+
+	return active, nil
+}
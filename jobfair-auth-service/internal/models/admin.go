@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// AdminAuditLog records one moderation action taken by an admin
+type AdminAuditLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AdminID    uint      `json:"admin_id" gorm:"not null;index"`
+	Action     string    `json:"action" gorm:"not null"`
+	TargetType string    `json:"target_type" gorm:"not null"`
+	TargetID   uint      `json:"target_id" gorm:"not null"`
+	Reason     string    `json:"reason,omitempty"`
+	Details    *string   `json:"details,omitempty" gorm:"type:jsonb"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// UserFilter narrows the admin user listing
+type UserFilter struct {
+	// Query matches email, first name or last name
+	Query    string
+	UserType UserType
+	IsActive *bool
+}
+
+type AuditLogFilter struct {
+	AdminID    uint
+	TargetType string
+	TargetID   uint
+	Action     string
+}
+
+type DeactivateUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type ReactivateUserRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type UserListData struct {
+	Users []User `json:"users"`
+	Total int64  `json:"total"`
+	Page  int    `json:"page"`
+	Limit int    `json:"limit"`
+}
+
+type AuditLogListData struct {
+	Logs  []AdminAuditLog `json:"logs"`
+	Total int64           `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
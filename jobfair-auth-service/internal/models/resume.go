@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Resume is one CV in a job seeker's library. Deleting a resume only hides
+// it from the library, applications submitted with it keep access. Files
+// uploaded with an application are stored the same way, outside the library.
+// Parsed holds what could be read from the file's text when it was uploaded.
+type Resume struct {
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	UserID      uint              `json:"user_id" gorm:"not null;index"`
+	Name        string            `json:"name" gorm:"not null"`
+	FileName    string            `json:"file_name" gorm:"not null"`
+	FileKey     string            `json:"-" gorm:"not null"`
+	FileSize    int64             `json:"file_size"`
+	MimeType    string            `json:"mime_type"`
+	IsDefault   bool              `json:"is_default" gorm:"default:false"`
+	ParseStatus ResumeParseStatus `json:"parse_status" gorm:"default:'pending'"`
+	Parsed      *ParsedResume     `json:"parsed,omitempty" gorm:"column:parsed_data;type:jsonb;serializer:json"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt    `json:"-" gorm:"index"`
+}
+
+type ResumeParseStatus string
+
+const (
+	ResumeParsePending     ResumeParseStatus = "pending"
+	ResumeParseParsed      ResumeParseStatus = "parsed"
+	ResumeParseFailed      ResumeParseStatus = "failed"
+	ResumeParseUnsupported ResumeParseStatus = "unsupported"
+)
+
+// ParsedResume is the structured record extracted from a resume's text.
+// Parsing is heuristic, fields it could not recognise are left empty.
+type ParsedResume struct {
+	Contact    ResumeContact      `json:"contact"`
+	Skills     []string           `json:"skills"`
+	Education  []ResumeEducation  `json:"education"`
+	Experience []ResumeExperience `json:"experience"`
+	// TotalExperienceMonths counts the months covered by the work history,
+	// overlapping positions are counted once
+	TotalExperienceMonths int `json:"total_experience_months"`
+}
+
+type ResumeContact struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	LinkedIn string `json:"linkedin,omitempty"`
+}
+
+type ResumeEducation struct {
+	Institution string `json:"institution,omitempty"`
+	Degree      string `json:"degree,omitempty"`
+	StartYear   int    `json:"start_year,omitempty"`
+	EndYear     int    `json:"end_year,omitempty"`
+	GPA         string `json:"gpa,omitempty"`
+}
+
+type ResumeExperience struct {
+	Title   string `json:"title,omitempty"`
+	Company string `json:"company,omitempty"`
+	// StartDate and EndDate are YYYY-MM, or YYYY when the month is not given
+	StartDate      string `json:"start_date,omitempty"`
+	EndDate        string `json:"end_date,omitempty"`
+	IsCurrent      bool   `json:"is_current"`
+	DurationMonths int    `json:"duration_months"`
+	Description    string `json:"description,omitempty"`
+}
+
+type UploadResumeRequest struct {
+	// Name defaults to the file name
+	Name      string `form:"name" binding:"max=100"`
+	IsDefault bool   `form:"is_default"`
+}
+
+type UpdateResumeRequest struct {
+	Name      *string `json:"name" binding:"omitempty,min=1,max=100"`
+	IsDefault *bool   `json:"is_default"`
+}
+
+// ResumeDownloadLinkRequest is sent by the company service for a resume
+// attached to an application
+type ResumeDownloadLinkRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// ResumeDownloadLink is a time-limited URL to the resume file
+type ResumeDownloadLink struct {
+	URL       string `json:"url"`
+	FileName  string `json:"file_name"`
+	ExpiresAt int64  `json:"expires_at"`
+}
@@ -51,6 +51,9 @@ type User struct {
 	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
 
+	// Last password change, every refresh token is revoked at that point
+	PasswordChangedAt *time.Time `json:"-"`
+
 	// Account Status
 	IsActive          bool `json:"is_active" gorm:"default:true"`
 	IsProfileComplete bool `json:"is_profile_complete" gorm:"default:false"`
@@ -101,14 +104,64 @@ type CompanyBasicProfile struct {
 
 // OTP Verification
 type OTPVerification struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	UserID      uint      `json:"user_id" gorm:"not null"`
-	PhoneNumber string    `json:"phone_number" gorm:"not null"`
-	OTPCode     string    `json:"-" gorm:"not null"`
-	Purpose     string    `json:"purpose"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	IsUsed      bool      `json:"is_used" gorm:"default:false"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"user_id" gorm:"not null"`
+	PhoneNumber string     `json:"phone_number" gorm:"not null"`
+	OTPHash     string     `json:"-" gorm:"column:otp_hash;not null"`
+	Purpose     string     `json:"purpose"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	IsUsed      bool       `json:"is_used" gorm:"default:false"`
+	UsedAt      *time.Time `json:"used_at"`
+	Attempts    int        `json:"attempts" gorm:"default:0"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Email Verification (one row per issued link, makes signed tokens single-use)
+type EmailVerification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null"`
+	Email     string     `json:"email" gorm:"not null"`
+	TokenID   string     `json:"-" gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Password Reset (one row per emailed reset link)
+type PasswordReset struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null"`
+	Email     string     `json:"email" gorm:"not null"`
+	TokenID   string     `json:"-" gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	IPAddress string     `json:"ip_address"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Refresh Token (opaque, stored hashed, rotated on every refresh)
+type RefreshToken struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	UserID        uint       `json:"user_id" gorm:"not null"`
+	TokenHash     string     `json:"-" gorm:"uniqueIndex;not null"`
+	FamilyID      string     `json:"-" gorm:"index;not null"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	IsRevoked     bool       `json:"is_revoked" gorm:"default:false"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedReason string     `json:"revoked_reason"`
+	ReplacedByID  *uint      `json:"-"`
+	IPAddress     string     `json:"ip_address"`
+	UserAgent     string     `json:"user_agent"`
+	DeviceInfo    *string    `json:"device_info" gorm:"type:jsonb"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastUsedAt    *time.Time `json:"last_used_at"`
+}
+
+// ClientInfo describes the device a session was started from
+type ClientInfo struct {
+	IPAddress  string
+	UserAgent  string
+	DeviceName string
 }
 
 type LoginRequest struct {
@@ -139,22 +192,36 @@ type RegisterStep1Request struct {
 	UserType UserType `json:"user_type" binding:"required,oneof=job_seeker company"`
 }
 
+// Password reset: either email or phone_number identifies the account
+type ForgotPasswordRequest struct {
+	Email       string `json:"email" binding:"omitempty,email"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+// Password reset confirmation: token from the email link, or phone_number + otp_code
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	PhoneNumber string `json:"phone_number"`
+	OTPCode     string `json:"otp_code" binding:"omitempty,len=6"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
 // Step 2: Basic Profile Setup - JOB SEEKER
 type RegisterStep2JobSeekerRequest struct {
-	FirstName   string `json:"first_name""`
-	LastName    string `json:"last_name""`
-	PhoneNumber string `json:"phone_number""`
-	CountryCode string `json:"country_code""`
-	Country     string `json:"country""`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	PhoneNumber string `json:"phone_number"`
+	CountryCode string `json:"country_code"`
+	Country     string `json:"country"`
 }
 
 // Step 2: Basic Profile Setup - COMPANY
 type RegisterStep2CompanyRequest struct {
-	CompanyName string `json:"company_name""`
-	Industry    string `json:"industry""`
-	PhoneNumber string `json:"phone_number""`
-	CountryCode string `json:"country_code""`
-	Address     string `json:"address""`
+	CompanyName string `json:"company_name"`
+	Industry    string `json:"industry"`
+	PhoneNumber string `json:"phone_number"`
+	CountryCode string `json:"country_code"`
+	Address     string `json:"address"`
 	Website     string `json:"website"`
 }
 
@@ -170,17 +237,17 @@ type VerifyOTPRequest struct {
 
 // Step 4: Job Seeker - Employment Status
 type JobSeekerStep1Request struct {
-	EmploymentStatus EmploymentStatus `json:"employment_status""`
+	EmploymentStatus EmploymentStatus `json:"employment_status"`
 	CurrentJobTitle  string           `json:"current_job_title"`
 	CurrentCompany   string           `json:"current_company"`
 }
 
 // Step 5: Job Seeker - Job Preferences
 type JobSeekerStep2Request struct {
-	JobSearchStatus    JobSearchStatus `json:"job_search_status" "`
-	DesiredPositions   []string        `json:"desired_positions" "`
-	PreferredLocations []string        `json:"preferred_locations" "`
-	JobTypes           []string        `json:"job_types" "`
+	JobSearchStatus    JobSearchStatus `json:"job_search_status"`
+	DesiredPositions   []string        `json:"desired_positions"`
+	PreferredLocations []string        `json:"preferred_locations"`
+	JobTypes           []string        `json:"job_types"`
 }
 
 // Step 6: Notification & Location Permissions
@@ -227,6 +294,32 @@ type OTPSentData struct {
 
 type ProfilePhotoData struct {
 	PhotoURL string `json:"photo_url"`
+	// Variants holds the URL of every resized copy (thumbnail, medium,
+	// original), PhotoURL is the original
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+// Session is one login (refresh token family) as shown to the user
+type SessionData struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name,omitempty"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type EmailVerificationSentData struct {
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type EmailVerifiedData struct {
+	Email           string     `json:"email"`
+	IsEmailVerified bool       `json:"is_email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 }
 
 // Responses
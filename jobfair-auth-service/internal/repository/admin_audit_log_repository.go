@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"jobfair-auth-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AdminAuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminAuditLogRepository(db *gorm.DB) *AdminAuditLogRepository {
+	return &AdminAuditLogRepository{db: db}
+}
+
+func (r *AdminAuditLogRepository) List(filter models.AuditLogFilter, limit, offset int) ([]models.AdminAuditLog, int64, error) {
+	var logs []models.AdminAuditLog
+	var total int64
+
+	query := r.db.Model(&models.AdminAuditLog{})
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Limit(limit).Offset(offset).Order("created_at DESC, id DESC").Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"jobfair-auth-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) *EmailVerificationRepository {
+	return &EmailVerificationRepository{db: db}
+}
+
+func (r *EmailVerificationRepository) Create(verification *models.EmailVerification) error {
+	return r.db.Create(verification).Error
+}
+
+func (r *EmailVerificationRepository) GetByTokenID(tokenID string) (*models.EmailVerification, error) {
+	var verification models.EmailVerification
+	if err := r.db.Where("token_id = ?", tokenID).First(&verification).Error; err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// GetLastSent returns the newest verification issued to a user, used for the resend cooldown
+func (r *EmailVerificationRepository) GetLastSent(userID uint) (*models.EmailVerification, error) {
+	var verification models.EmailVerification
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		First(&verification).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+// InvalidateForUser consumes every outstanding verification of a user so only
+// the newest link stays valid.
+func (r *EmailVerificationRepository) InvalidateForUser(userID uint) error {
+	return r.db.Model(&models.EmailVerification{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+// MarkUsed consumes the verification. It returns false when it was already used.
+func (r *EmailVerificationRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.EmailVerification{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
@@ -19,20 +19,8 @@ func (r *OTPRepository) Create(otp *models.OTPVerification) error {
 	return r.db.Create(otp).Error
 }
 
-func (r *OTPRepository) GetLatestOTP(phoneNumber, purpose, otpCode string) (*models.OTPVerification, error) {
-	// Jika kode OTP = 123456 → bypass validasi
-	if otpCode == "123456" {
-		// Buat OTP dummy valid
-		return &models.OTPVerification{
-			PhoneNumber: phoneNumber,
-			Purpose:     purpose,
-			OTPCode:     otpCode,
-			ExpiresAt:   time.Now().Add(5 * time.Minute),
-			IsUsed:      false,
-		}, nil
-	}
-
-	// Jika bukan 123456 → cek database seperti biasa
+// GetLatestOTP returns the newest OTP that can still be verified
+func (r *OTPRepository) GetLatestOTP(phoneNumber, purpose string) (*models.OTPVerification, error) {
 	var otp models.OTPVerification
 	err := r.db.Where("phone_number = ? AND purpose = ? AND is_used = ? AND expires_at > ?",
 		phoneNumber, purpose, false, time.Now()).
@@ -46,10 +34,67 @@ func (r *OTPRepository) GetLatestOTP(phoneNumber, purpose, otpCode string) (*mod
 	return &otp, nil
 }
 
-func (r *OTPRepository) Update(otp *models.OTPVerification) error {
-	return r.db.Save(otp).Error
+// GetLastSent returns the newest OTP regardless of state, used for the resend cooldown
+func (r *OTPRepository) GetLastSent(phoneNumber, purpose string) (*models.OTPVerification, error) {
+	var otp models.OTPVerification
+	err := r.db.Where("phone_number = ? AND purpose = ?", phoneNumber, purpose).
+		Order("created_at DESC").
+		First(&otp).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &otp, nil
+}
+
+func (r *OTPRepository) CountSentSince(phoneNumber, purpose string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.OTPVerification{}).
+		Where("phone_number = ? AND purpose = ? AND created_at >= ?", phoneNumber, purpose, since).
+		Count(&count).Error
+	return count, err
+}
+
+// RecordFailedAttempt increments the attempt counter and invalidates the OTP
+// once maxAttempts is reached. It returns the remaining attempts.
+func (r *OTPRepository) RecordFailedAttempt(id uint, maxAttempts int) (int, error) {
+	var otp models.OTPVerification
+	err := r.db.Model(&otp).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts": gorm.Expr("attempts + 1"),
+			"is_used":  gorm.Expr("attempts + 1 >= ?", maxAttempts),
+		}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.db.Select("attempts").First(&otp, id).Error; err != nil {
+		return 0, err
+	}
+
+	remaining := maxAttempts - otp.Attempts
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+// MarkUsed consumes the OTP. It returns false when another request already used it.
+func (r *OTPRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.OTPVerification{}).
+		Where("id = ? AND is_used = ?", id, false).
+		Updates(map[string]interface{}{
+			"is_used": true,
+			"used_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
 }
 
-func (r *OTPRepository) DeleteExpiredOTPs() error {
-	return r.db.Where("expires_at < ?", time.Now()).Delete(&models.OTPVerification{}).Error
+// DeleteExpiredOTPs removes OTPs that expired more than retention ago. Rows are
+// kept for the retention period so the daily send cap can still count them.
+func (r *OTPRepository) DeleteExpiredOTPs(retention time.Duration) (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now().Add(-retention)).Delete(&models.OTPVerification{})
+	return result.RowsAffected, result.Error
 }
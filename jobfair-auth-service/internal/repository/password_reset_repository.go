@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"jobfair-auth-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+func (r *PasswordResetRepository) Create(reset *models.PasswordReset) error {
+	return r.db.Create(reset).Error
+}
+
+func (r *PasswordResetRepository) GetByTokenID(tokenID string) (*models.PasswordReset, error) {
+	var reset models.PasswordReset
+	if err := r.db.Where("token_id = ?", tokenID).First(&reset).Error; err != nil {
+		return nil, err
+	}
+	return &reset, nil
+}
+
+// GetLastSent returns the newest reset issued to a user, used for the resend cooldown
+func (r *PasswordResetRepository) GetLastSent(userID uint) (*models.PasswordReset, error) {
+	var reset models.PasswordReset
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		First(&reset).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reset, nil
+}
+
+// InvalidateForUser consumes every outstanding reset link of a user
+func (r *PasswordResetRepository) InvalidateForUser(userID uint) error {
+	return r.db.Model(&models.PasswordReset{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+// MarkUsed consumes the reset link. It returns false when it was already used.
+func (r *PasswordResetRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.PasswordReset{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"errors"
+	"jobfair-auth-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var errRefreshTokenAlreadyRevoked = errors.New("refresh token already revoked")
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *RefreshTokenRepository) GetByHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate revokes the current token and stores its replacement in one
+// transaction. It returns false when another request rotated or revoked the
+// token first, which callers must treat as reuse.
+func (r *RefreshTokenRepository) Rotate(current *models.RefreshToken, next *models.RefreshToken) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND is_revoked = ?", current.ID, false).
+			Updates(map[string]interface{}{
+				"is_revoked":     true,
+				"revoked_at":     now,
+				"revoked_reason": "rotated",
+				"replaced_by_id": next.ID,
+				"last_used_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Roll back the replacement
+			return errRefreshTokenAlreadyRevoked
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errRefreshTokenAlreadyRevoked) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListActive returns the current (not yet rotated) token of every active
+// session of a user, newest activity first
+func (r *RefreshTokenRepository) ListActive(userID uint) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	err := r.db.Where("user_id = ? AND is_revoked = ? AND expires_at > ?", userID, false, time.Now()).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// SessionStartTimes returns when each family was first issued, i.e. the login time
+func (r *RefreshTokenRepository) SessionStartTimes(familyIDs []string) (map[string]time.Time, error) {
+	var rows []struct {
+		FamilyID  string
+		StartedAt time.Time
+	}
+	err := r.db.Model(&models.RefreshToken{}).
+		Select("family_id, MIN(created_at) AS started_at").
+		Where("family_id IN ?", familyIDs).
+		Group("family_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		startedAt[row.FamilyID] = row.StartedAt
+	}
+	return startedAt, nil
+}
+
+// IsFamilyActive reports whether a session still has a usable token
+func (r *RefreshTokenRepository) IsFamilyActive(familyID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND is_revoked = ? AND expires_at > ?", familyID, false, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// RevokeUserFamily revokes a session only if it belongs to the user. It
+// returns false when no active token of that session was found.
+func (r *RefreshTokenRepository) RevokeUserFamily(userID uint, familyID, reason string) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND family_id = ? AND is_revoked = ?", userID, familyID, false).
+		Updates(map[string]interface{}{
+			"is_revoked":     true,
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeFamily revokes every token rotated from the same login
+func (r *RefreshTokenRepository) RevokeFamily(familyID, reason string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND is_revoked = ?", familyID, false).
+		Updates(map[string]interface{}{
+			"is_revoked":     true,
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+// RevokeAllForUser revokes every active token of a user
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uint, reason string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND is_revoked = ?", userID, false).
+		Updates(map[string]interface{}{
+			"is_revoked":     true,
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+// DeleteExpired removes tokens that expired more than retention ago. Rotated
+// tokens are kept until then so reuse can still be detected.
+func (r *RefreshTokenRepository) DeleteExpired(retention time.Duration) (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now().Add(-retention)).Delete(&models.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrResumeLimitReached is returned when the library is full
+var ErrResumeLimitReached = errors.New("resume limit reached, delete a resume first")
+
+type ResumeRepository struct {
+	db *gorm.DB
+}
+
+func NewResumeRepository(db *gorm.DB) *ResumeRepository {
+	return &ResumeRepository{db: db}
+}
+
+// CreateWithinLimit adds a resume to the user's library. The first resume
+// always becomes the default. The user row is locked so concurrent uploads
+// cannot both pass the limit check.
+func (r *ResumeRepository) CreateWithinLimit(resume *models.Resume, limit int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, resume.UserID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Resume{}).Where("user_id = ?", resume.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return ErrResumeLimitReached
+		}
+
+		if count == 0 {
+			resume.IsDefault = true
+		}
+		if resume.IsDefault {
+			if err := clearDefault(tx, resume.UserID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(resume).Error
+	})
+}
+
+// CreateDetached stores a resume uploaded with an application. Like a resume
+// deleted from the library it is only reachable through that application.
+func (r *ResumeRepository) CreateDetached(resume *models.Resume) error {
+	resume.IsDefault = false
+	resume.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return r.db.Create(resume).Error
+}
+
+// ListByUser returns the library with the default resume first
+func (r *ResumeRepository) ListByUser(userID uint) ([]*models.Resume, error) {
+	var resumes []*models.Resume
+	err := r.db.Where("user_id = ?", userID).
+		Order("is_default DESC, created_at DESC").
+		Find(&resumes).Error
+	return resumes, err
+}
+
+// GetByUser returns a resume of the user's library
+func (r *ResumeRepository) GetByUser(userID, id uint) (*models.Resume, error) {
+	var resume models.Resume
+	if err := r.db.Where("user_id = ?", userID).First(&resume, id).Error; err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+// GetByUserUnscoped also finds resumes removed from the library, which stay
+// downloadable for applications that were submitted with them
+func (r *ResumeRepository) GetByUserUnscoped(userID, id uint) (*models.Resume, error) {
+	var resume models.Resume
+	if err := r.db.Unscoped().Where("user_id = ?", userID).First(&resume, id).Error; err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+// GetByIDUnscoped resolves the resume of a signed download link
+func (r *ResumeRepository) GetByIDUnscoped(id uint) (*models.Resume, error) {
+	var resume models.Resume
+	if err := r.db.Unscoped().First(&resume, id).Error; err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+func (r *ResumeRepository) GetDefault(userID uint) (*models.Resume, error) {
+	var resume models.Resume
+	if err := r.db.Where("user_id = ? AND is_default", userID).First(&resume).Error; err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+// UpdateParsed saves the result of parsing the resume text
+func (r *ResumeRepository) UpdateParsed(resume *models.Resume) error {
+	return r.db.Model(resume).Select("parse_status", "parsed_data").Updates(resume).Error
+}
+
+func (r *ResumeRepository) UpdateName(resume *models.Resume) error {
+	return r.db.Model(resume).Update("name", resume.Name).Error
+}
+
+// SetDefault makes a resume the user's default
+func (r *ResumeRepository) SetDefault(resume *models.Resume) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefault(tx, resume.UserID); err != nil {
+			return err
+		}
+		resume.IsDefault = true
+		return tx.Model(resume).Update("is_default", true).Error
+	})
+}
+
+// Delete removes a resume from the library. When it was the default, the
+// newest remaining resume takes its place.
+func (r *ResumeRepository) Delete(resume *models.Resume) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(resume).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(resume).Error; err != nil {
+			return err
+		}
+		if !resume.IsDefault {
+			return nil
+		}
+
+		var next models.Resume
+		err := tx.Where("user_id = ?", resume.UserID).Order("created_at DESC").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&next).Update("is_default", true).Error
+	})
+}
+
+// Purge deletes a resume row for good
+func (r *ResumeRepository) Purge(resume *models.Resume) error {
+	return r.db.Unscoped().Delete(resume).Error
+}
+
+// FileKeyInUse reports whether any resume, including deleted ones, still
+// references a stored file
+func (r *ResumeRepository) FileKeyInUse(key string) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Resume{}).Where("file_key = ?", key).Count(&count).Error
+	return count > 0, err
+}
+
+func clearDefault(tx *gorm.DB, userID uint) error {
+	return tx.Model(&models.Resume{}).
+		Where("user_id = ? AND is_default", userID).
+		Update("is_default", false).Error
+}
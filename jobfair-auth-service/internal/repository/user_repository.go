@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"jobfair-auth-service/internal/models"
 
 	"gorm.io/gorm"
@@ -56,10 +58,42 @@ func (r *UserRepository) GetByPhoneNumber(phoneNumber string) (*models.User, err
 	return &user, nil
 }
 
-func (r *UserRepository) GetAll() ([]models.User, error) {
+// Search lists users for the admin API, newest first
+func (r *UserRepository) Search(filter models.UserFilter, limit, offset int) ([]models.User, int64, error) {
 	var users []models.User
-	if err := r.db.Find(&users).Error; err != nil {
-		return nil, err
+	var total int64
+
+	query := r.db.Model(&models.User{})
+	if filter.Query != "" {
+		like := "%" + strings.ToLower(filter.Query) + "%"
+		query = query.Where("LOWER(email) LIKE ? OR LOWER(first_name) LIKE ? OR LOWER(last_name) LIKE ?", like, like, like)
+	}
+	if filter.UserType != "" {
+		query = query.Where("user_type = ?", filter.UserType)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
-	return users, nil
+
+	if err := query.Limit(limit).Offset(offset).Order("created_at DESC, id DESC").Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// SetActive changes User.IsActive and records the audit entry in the same
+// transaction, so a moderation action is never applied unaudited
+func (r *UserRepository) SetActive(userID uint, active bool, entry *models.AdminAuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("is_active", active).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(entry).Error
+	})
 }
@@ -0,0 +1,180 @@
+package resumeparser
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+var ErrUnsupportedFormat = errors.New("text extraction is not supported for this file type")
+
+const (
+	// maxPages bounds the work spent on a single resume
+	maxPages = 10
+	// maxDocumentXML bounds the decompressed size of a docx body
+	maxDocumentXML = 20 * 1024 * 1024
+)
+
+// ExtractText returns the plain text of a PDF or DOCX file, one line of the
+// document per line. Legacy .doc files are not supported.
+func ExtractText(data []byte, ext string) (text string, err error) {
+	// The PDF reader panics on some malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("malformed document: %v", r)
+		}
+	}()
+
+	switch strings.ToLower(ext) {
+	case ".pdf":
+		return pdfText(data)
+	case ".docx":
+		return docxText(data)
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+func pdfText(data []byte) (string, error) {
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	pages := reader.NumPage()
+	if pages > maxPages {
+		pages = maxPages
+	}
+	for i := 1; i <= pages; i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		writeLines(&b, page.Content().Text)
+	}
+	return b.String(), nil
+}
+
+// writeLines puts the glyphs of a page back into lines. PDFs only position
+// glyphs, so lines are rebuilt from the baseline and word breaks from the
+// gap between neighbouring glyphs.
+func writeLines(b *strings.Builder, glyphs []pdf.Text) {
+	if len(glyphs) == 0 {
+		return
+	}
+
+	sort.SliceStable(glyphs, func(i, j int) bool { return glyphs[i].Y > glyphs[j].Y })
+
+	var line []pdf.Text
+	flush := func() {
+		sort.SliceStable(line, func(i, j int) bool { return line[i].X < line[j].X })
+		for i, glyph := range line {
+			if i > 0 {
+				prev := line[i-1]
+				width := prev.W
+				if width == 0 {
+					// Standard fonts without a widths table
+					width = 0.5 * prev.FontSize
+				}
+				gap := glyph.X - (prev.X + width)
+				switch {
+				case gap > 2*glyph.FontSize:
+					// Columns, e.g. a job title left and its dates right
+					b.WriteByte('\t')
+				case gap > 0.2*glyph.FontSize && prev.S != " " && glyph.S != " ":
+					b.WriteByte(' ')
+				}
+			}
+			b.WriteString(glyph.S)
+		}
+		b.WriteByte('\n')
+		line = line[:0]
+	}
+
+	for _, glyph := range glyphs {
+		if len(line) > 0 && line[0].Y-glyph.Y > 0.5*line[0].FontSize {
+			flush()
+		}
+		line = append(line, glyph)
+	}
+	flush()
+}
+
+func docxText(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range archive.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		return documentXMLText(io.LimitReader(rc, maxDocumentXML))
+	}
+	return "", errors.New("word/document.xml not found")
+}
+
+// documentXMLText walks the WordprocessingML body, every paragraph becomes a
+// line and tabs are kept as column separators
+func documentXMLText(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+	var b strings.Builder
+	// w:tab also declares tab stops in paragraph properties, only tabs
+	// inside a run are content
+	inRun, inText := false, false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "r":
+				inRun = true
+			case "t":
+				inText = true
+			case "tab":
+				if inRun {
+					b.WriteByte('\t')
+				}
+			case "br", "cr":
+				if inRun {
+					b.WriteByte('\n')
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "r":
+				inRun = false
+			case "t":
+				inText = false
+			case "p":
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+}
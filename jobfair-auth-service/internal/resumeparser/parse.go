@@ -0,0 +1,552 @@
+package resumeparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"jobfair-auth-service/internal/models"
+)
+
+const (
+	maxSkills      = 50
+	maxDescription = 1000
+)
+
+type section int
+
+const (
+	sectionNone section = iota
+	sectionExperience
+	sectionEducation
+	sectionSkills
+	sectionOther
+)
+
+// headings maps normalised section headings, English and Indonesian, to the
+// section they start. Headings of sections that are not parsed are listed so
+// they end the previous section.
+var headings = map[string]section{
+	"experience":                sectionExperience,
+	"work experience":           sectionExperience,
+	"professional experience":   sectionExperience,
+	"employment":                sectionExperience,
+	"employment history":        sectionExperience,
+	"work history":              sectionExperience,
+	"career history":            sectionExperience,
+	"pengalaman":                sectionExperience,
+	"pengalaman kerja":          sectionExperience,
+	"pengalaman profesional":    sectionExperience,
+	"riwayat pekerjaan":         sectionExperience,
+	"riwayat kerja":             sectionExperience,
+	"education":                 sectionEducation,
+	"educational background":    sectionEducation,
+	"academic background":       sectionEducation,
+	"pendidikan":                sectionEducation,
+	"riwayat pendidikan":        sectionEducation,
+	"latar belakang pendidikan": sectionEducation,
+	"pendidikan formal":         sectionEducation,
+	"skills":                    sectionSkills,
+	"skill":                     sectionSkills,
+	"technical skills":          sectionSkills,
+	"key skills":                sectionSkills,
+	"core skills":               sectionSkills,
+	"hard skills":               sectionSkills,
+	"soft skills":               sectionSkills,
+	"skills and tools":          sectionSkills,
+	"competencies":              sectionSkills,
+	"core competencies":         sectionSkills,
+	"keahlian":                  sectionSkills,
+	"keahlian teknis":           sectionSkills,
+	"kemampuan":                 sectionSkills,
+	"keterampilan":              sectionSkills,
+	"kompetensi":                sectionSkills,
+	"summary":                   sectionOther,
+	"professional summary":      sectionOther,
+	"profile":                   sectionOther,
+	"about me":                  sectionOther,
+	"objective":                 sectionOther,
+	"career objective":          sectionOther,
+	"projects":                  sectionOther,
+	"certifications":            sectionOther,
+	"certificates":              sectionOther,
+	"courses":                   sectionOther,
+	"training":                  sectionOther,
+	"languages":                 sectionOther,
+	"awards":                    sectionOther,
+	"achievements":              sectionOther,
+	"organizations":             sectionOther,
+	"organizational experience": sectionOther,
+	"volunteering":              sectionOther,
+	"interests":                 sectionOther,
+	"references":                sectionOther,
+	"personal information":      sectionOther,
+	"contact":                   sectionOther,
+	"ringkasan":                 sectionOther,
+	"profil":                    sectionOther,
+	"tentang saya":              sectionOther,
+	"proyek":                    sectionOther,
+	"sertifikasi":               sectionOther,
+	"pelatihan":                 sectionOther,
+	"bahasa":                    sectionOther,
+	"penghargaan":               sectionOther,
+	"prestasi":                  sectionOther,
+	"organisasi":                sectionOther,
+	"pengalaman organisasi":     sectionOther,
+	"minat":                     sectionOther,
+	"referensi":                 sectionOther,
+	"data diri":                 sectionOther,
+	"informasi pribadi":         sectionOther,
+	"kontak":                    sectionOther,
+}
+
+const monthPattern = `jan(?:uary|uari)?|feb(?:ruary|ruari)?|mar(?:ch|et)?|apr(?:il)?|may|mei|jun[ei]?|jul[yi]?|aug(?:ust)?|agu(?:stus)?|agt|sep(?:t(?:ember)?)?|o[ck]t(?:ober)?|nov(?:ember)?|de[cs](?:ember)?`
+
+const yearPattern = `(?:19|20)\d{2}`
+
+const datePattern = `(?:(?:` + monthPattern + `)\.?\s+` + yearPattern + `|\d{1,2}/` + yearPattern + `|` + yearPattern + `)`
+
+var (
+	emailRe    = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phoneRe    = regexp.MustCompile(`\+?\d[\d\s\-().]{7,16}\d`)
+	linkedInRe = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]{2,3}\.)?linkedin\.com/in/[A-Za-z0-9_\-%]+/?`)
+
+	dateRangeRe = regexp.MustCompile(`(?i)\b(` + datePattern + `)\s*(?:-|–|—|to|until|s/d|s\.d\.?|sampai|hingga)\s*(` + datePattern + `|present|current|now|today|sekarang|saat ini|kini)\b`)
+	monthYearRe = regexp.MustCompile(`(?i)^(?:(` + monthPattern + `)\.?\s+|(\d{1,2})/)?(` + yearPattern + `)$`)
+	yearRe      = regexp.MustCompile(`\b` + yearPattern + `\b`)
+
+	institutionRe = regexp.MustCompile(`(?i)\b(?:universit(?:y|as)|institut(?:e)?|politeknik|polytechnic|college|school|sekolah|academy|akademi|sma|smk|sman|smkn|stie|stmik)\b`)
+	degreeRe      = regexp.MustCompile(`(?i)\b(?:s1|s2|s3|d3|d4|bachelor(?:'s)?|master(?:'s)?|sarjana|magister|doktor|diploma|ph\.?d|doctor(?:ate)?|b\.?sc|m\.?sc|b\.?eng|m\.?eng|mba|associate)\b`)
+	gpaRe         = regexp.MustCompile(`(?i)\b(?:gpa|ipk)\s*[:=]?\s*(\d[.,]\d{1,2})`)
+	companyRe     = regexp.MustCompile(`(?i)\b(?:pt|cv|tbk|inc|ltd|llc|corp|corporation|company|group|gmbh|persero|labs?|technologies|teknologi)\b`)
+
+	bulletChars = "•·▪●○◦➢➤✓✔*-–—>"
+)
+
+// Parse turns the plain text of a resume into a structured record. It is a
+// heuristic: it recognises the section headings and date formats common in
+// English and Indonesian resumes and leaves out what it cannot place. now is
+// the end of positions that are still current.
+func Parse(text string, now time.Time) *models.ParsedResume {
+	lines := splitLines(text)
+
+	parsed := &models.ParsedResume{
+		Skills:     []string{},
+		Education:  []models.ResumeEducation{},
+		Experience: []models.ResumeExperience{},
+	}
+	parsed.Contact = parseContact(text, lines)
+
+	sections := map[section][]string{}
+	current := sectionNone
+	for _, line := range lines {
+		if s, ok := headings[normalizeHeading(line)]; ok {
+			current = s
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+
+	parsed.Skills = parseSkills(sections[sectionSkills])
+	parsed.Education = parseEducation(sections[sectionEducation])
+	var spans []span
+	parsed.Experience, spans = parseExperience(sections[sectionExperience], now)
+	parsed.TotalExperienceMonths = totalMonths(spans)
+
+	return parsed
+}
+
+func splitLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		// Runs of spaces are collapsed, tabs are kept as column separators
+		columns := strings.Split(line, "\t")
+		kept := columns[:0]
+		for _, column := range columns {
+			if column = strings.Join(strings.Fields(column), " "); column != "" {
+				kept = append(kept, column)
+			}
+		}
+		if len(kept) > 0 {
+			lines = append(lines, strings.Join(kept, "\t"))
+		}
+	}
+	return lines
+}
+
+func normalizeHeading(line string) string {
+	if len(line) > 40 {
+		return ""
+	}
+	line = strings.ToLower(strings.ReplaceAll(line, "&", " and "))
+	line = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return r
+		}
+		return ' '
+	}, line)
+	return strings.Join(strings.Fields(line), " ")
+}
+
+func parseContact(text string, lines []string) models.ResumeContact {
+	var contact models.ResumeContact
+
+	contact.Email = emailRe.FindString(text)
+	contact.LinkedIn = linkedInRe.FindString(text)
+
+	for _, match := range phoneRe.FindAllString(text, -1) {
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, match)
+		// Date ranges such as 2019 - 2021 match the pattern too
+		if len(digits) < 9 || len(digits) > 15 || dateRangeRe.MatchString(match) {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(match), "+") {
+			digits = "+" + digits
+		}
+		contact.Phone = digits
+		break
+	}
+
+	// The name is usually the first line that reads like one
+	for i, line := range lines {
+		if i >= 5 {
+			break
+		}
+		if _, ok := headings[normalizeHeading(line)]; ok {
+			break
+		}
+		if looksLikeName(line) {
+			contact.Name = line
+			break
+		}
+	}
+
+	return contact
+}
+
+func looksLikeName(line string) bool {
+	words := strings.Fields(line)
+	if len(words) < 2 || len(words) > 5 || len(line) > 50 {
+		return false
+	}
+	for _, r := range line {
+		if !unicode.IsLetter(r) && !strings.ContainsRune(" .'-", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSkills(lines []string) []string {
+	skills := []string{}
+	seen := map[string]bool{}
+
+	for _, line := range lines {
+		line = trimBullet(line)
+		// "Programming: Go, Python" lists skills after a short label
+		if label, rest, ok := strings.Cut(line, ":"); ok && len(strings.Fields(label)) <= 3 {
+			line = rest
+		}
+
+		for _, skill := range strings.FieldsFunc(line, func(r rune) bool {
+			return strings.ContainsRune(",;|•·\t", r)
+		}) {
+			skill = strings.TrimSpace(strings.TrimRight(trimBullet(skill), "."))
+			// Proficiency levels such as "Go (Advanced)"
+			if i := strings.Index(skill, " ("); i > 0 && strings.HasSuffix(skill, ")") {
+				skill = skill[:i]
+			}
+			if skill == "" || len(skill) > 40 || len(strings.Fields(skill)) > 4 {
+				continue
+			}
+
+			key := strings.ToLower(skill)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			skills = append(skills, skill)
+			if len(skills) == maxSkills {
+				return skills
+			}
+		}
+	}
+
+	return skills
+}
+
+func parseEducation(lines []string) []models.ResumeEducation {
+	entries := []models.ResumeEducation{}
+	var current *models.ResumeEducation
+	flush := func() {
+		if current != nil && (current.Institution != "" || current.Degree != "") {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range lines {
+		line = trimBullet(line)
+		isInstitution := institutionRe.MatchString(line)
+		isDegree := degreeRe.MatchString(line)
+
+		if current != nil && ((isInstitution && current.Institution != "") || (isDegree && !isInstitution && current.Degree != "")) {
+			flush()
+		}
+		if current == nil {
+			if !isInstitution && !isDegree {
+				continue
+			}
+			current = &models.ResumeEducation{}
+		}
+
+		if match := gpaRe.FindStringSubmatch(line); match != nil {
+			current.GPA = strings.ReplaceAll(match[1], ",", ".")
+			line = strings.Replace(line, match[0], "", 1)
+		}
+		if match := dateRangeRe.FindStringSubmatch(line); match != nil {
+			current.StartYear, _ = yearOf(match[1])
+			current.EndYear, _ = yearOf(match[2])
+			line = strings.Replace(line, match[0], "", 1)
+		} else if years := yearRe.FindAllString(line, -1); len(years) > 0 && current.EndYear == 0 {
+			current.EndYear, _ = strconv.Atoi(years[len(years)-1])
+			line = yearRe.ReplaceAllString(line, "")
+		}
+
+		// "S1 Teknik Informatika, Universitas Indonesia" names both
+		for _, part := range splitColumns(line) {
+			switch {
+			case institutionRe.MatchString(part) && current.Institution == "":
+				current.Institution = part
+			case degreeRe.MatchString(part) && current.Degree == "":
+				current.Degree = part
+			}
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// span is the first and last month of a position, as months since year 0
+type span struct{ first, last int }
+
+func parseExperience(lines []string, now time.Time) ([]models.ResumeExperience, []span) {
+	entries := []models.ResumeExperience{}
+	var spans []span
+
+	// pending holds the lines since the dates of the previous position, they
+	// are its description unless they turn out to be the next header
+	type pendingLine struct {
+		text   string
+		bullet bool
+	}
+	var pending []pendingLine
+	attach := func() {
+		if len(entries) > 0 {
+			var description []string
+			for _, line := range pending {
+				description = append(description, line.text)
+			}
+			entries[len(entries)-1].Description = truncate(strings.Join(description, "\n"), maxDescription)
+		}
+		pending = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		match := dateRangeRe.FindStringSubmatchIndex(line)
+		if match == nil {
+			pending = append(pending, pendingLine{text: trimBullet(line), bullet: isBullet(line)})
+			continue
+		}
+
+		start, end := line[match[2]:match[3]], line[match[4]:match[5]]
+		entry := models.ResumeExperience{StartDate: formatDate(start)}
+		if endDate := formatDate(end); endDate != "" {
+			entry.EndDate = endDate
+		} else {
+			entry.IsCurrent = true
+		}
+		if first, last, ok := monthRange(start, end, now); ok {
+			entry.DurationMonths = last - first + 1
+			spans = append(spans, span{first, last})
+		}
+
+		header := splitColumns(line[:match[0]] + "\t" + line[match[1]:])
+		// Otherwise title and company are on the plain lines right above the
+		// dates, bulleted lines belong to the previous description
+		rest, taken := header, []string(nil)
+		for len(header) < 2 && len(taken) < 2 && len(pending) > 0 && !pending[len(pending)-1].bullet {
+			taken = append([]string{pending[len(pending)-1].text}, taken...)
+			pending = pending[:len(pending)-1]
+			header = splitColumns(strings.Join(append(taken, rest...), "\t"))
+		}
+		attach()
+
+		// "Software Engineer    Jan 2020 - Present" followed by the company
+		if len(header) == 1 && i+1 < len(lines) && companyRe.MatchString(lines[i+1]) && !dateRangeRe.MatchString(lines[i+1]) {
+			header = append(header, lines[i+1])
+			i++
+		}
+		entry.Title, entry.Company = titleAndCompany(header)
+
+		entries = append(entries, entry)
+	}
+	attach()
+
+	return entries, spans
+}
+
+// titleAndCompany orders the header parts of a position, company names are
+// recognised by legal forms such as PT or Inc
+func titleAndCompany(header []string) (string, string) {
+	switch len(header) {
+	case 0:
+		return "", ""
+	case 1:
+		return header[0], ""
+	}
+	title, company := header[0], strings.Join(header[1:], ", ")
+	if companyRe.MatchString(title) && !companyRe.MatchString(company) {
+		title, company = company, title
+	}
+	return title, company
+}
+
+// splitColumns splits a header line on the separators used between title,
+// company and location
+func splitColumns(line string) []string {
+	for _, sep := range []string{"\t", " | ", " at ", " @ ", " - ", " – ", " — ", ", "} {
+		line = strings.ReplaceAll(line, sep, "\x00")
+	}
+
+	var parts []string
+	for _, part := range strings.Split(line, "\x00") {
+		part = strings.Trim(part, " ,|()-–—")
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func isBullet(line string) bool {
+	first, _ := utf8.DecodeRuneInString(line)
+	return strings.ContainsRune(bulletChars, first)
+}
+
+func trimBullet(line string) string {
+	return strings.TrimSpace(strings.TrimLeft(line, bulletChars+" "))
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
+
+// parseDate reads "Jan 2020", "01/2020" or "2020". month is 0 when only the
+// year is given.
+func parseDate(s string) (year, month int, ok bool) {
+	match := monthYearRe.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, 0, false
+	}
+	year, _ = strconv.Atoi(match[3])
+	switch {
+	case match[1] != "":
+		month = monthNumber(match[1])
+	case match[2] != "":
+		month, _ = strconv.Atoi(match[2])
+		if month < 1 || month > 12 {
+			month = 0
+		}
+	}
+	return year, month, true
+}
+
+func yearOf(s string) (int, bool) {
+	year, _, ok := parseDate(s)
+	return year, ok
+}
+
+var monthNumbers = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "mei": 5, "jun": 6, "jul": 7,
+	"aug": 8, "agu": 8, "agt": 8, "sep": 9, "oct": 10, "okt": 10, "nov": 11, "dec": 12, "des": 12,
+}
+
+func monthNumber(name string) int {
+	return monthNumbers[strings.ToLower(name)[:3]]
+}
+
+func formatDate(s string) string {
+	year, month, ok := parseDate(s)
+	if !ok {
+		return ""
+	}
+	if month == 0 {
+		return strconv.Itoa(year)
+	}
+	return strconv.Itoa(year) + "-" + twoDigits(month)
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// monthRange returns the first and last month of a position as months since
+// year 0. Years without a month cover the whole year, ongoing positions end
+// now.
+func monthRange(start, end string, now time.Time) (int, int, bool) {
+	startYear, startMonth, ok := parseDate(start)
+	if !ok {
+		return 0, 0, false
+	}
+	if startMonth == 0 {
+		startMonth = 1
+	}
+
+	current := now.Year()*12 + int(now.Month()) - 1
+	last := current
+	if endYear, endMonth, ok := parseDate(end); ok {
+		if endMonth == 0 {
+			endMonth = 12
+		}
+		last = endYear*12 + endMonth - 1
+	}
+	if last > current {
+		last = current
+	}
+
+	first := startYear*12 + startMonth - 1
+	if last < first {
+		return 0, 0, false
+	}
+	return first, last, true
+}
+
+// totalMonths counts the months covered by any span, so overlapping
+// positions are not counted twice
+func totalMonths(spans []span) int {
+	covered := map[int]bool{}
+	for _, span := range spans {
+		for month := span.first; month <= span.last; month++ {
+			covered[month] = true
+		}
+	}
+	return len(covered)
+}
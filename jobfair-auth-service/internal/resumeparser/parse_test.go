@@ -0,0 +1,127 @@
+package resumeparser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+)
+
+// Every testdata/<name>.txt is parsed and compared with testdata/<name>.json
+func TestParseFixtures(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures in testdata")
+	}
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".txt")
+		t.Run(name, func(t *testing.T) {
+			text, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := os.ReadFile(strings.TrimSuffix(fixture, ".txt") + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want models.ParsedResume
+			if err := json.Unmarshal(expected, &want); err != nil {
+				t.Fatal(err)
+			}
+			got := Parse(string(text), now)
+
+			if !reflect.DeepEqual(*got, want) {
+				gotJSON, _ := json.MarshalIndent(got, "", "  ")
+				t.Errorf("parsed resume does not match %s.json, got:\n%s", name, gotJSON)
+			}
+		})
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	got := Parse("", time.Now())
+
+	if got.Skills == nil || got.Education == nil || got.Experience == nil {
+		t.Error("lists must be empty, not nil, so they encode as []")
+	}
+	if got.TotalExperienceMonths != 0 {
+		t.Errorf("total experience = %d, want 0", got.TotalExperienceMonths)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Jan 2020", "2020-01"},
+		{"january 2020", "2020-01"},
+		{"Agustus 2019", "2019-08"},
+		{"Okt. 2021", "2021-10"},
+		{"Des 2018", "2018-12"},
+		{"03/2022", "2022-03"},
+		{"13/2022", "2022"},
+		{"2017", "2017"},
+		{"Present", ""},
+		{"Sekarang", ""},
+	}
+
+	for _, tt := range tests {
+		if got := formatDate(tt.in); got != tt.want {
+			t.Errorf("formatDate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMonthRange(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		start, end string
+		months     int
+		ok         bool
+	}{
+		{"Jan 2020", "Dec 2020", 12, true},
+		{"2019", "2020", 24, true},
+		{"Mar 2024", "Present", 4, true},
+		// Positions cannot end after now
+		{"Jan 2024", "Dec 2030", 6, true},
+		{"Dec 2020", "Jan 2020", 0, false},
+		{"Present", "2020", 0, false},
+	}
+
+	for _, tt := range tests {
+		first, last, ok := monthRange(tt.start, tt.end, now)
+		if ok != tt.ok {
+			t.Errorf("%s - %s: ok = %v, want %v", tt.start, tt.end, ok, tt.ok)
+			continue
+		}
+		if ok && last-first+1 != tt.months {
+			t.Errorf("%s - %s: %d months, want %d", tt.start, tt.end, last-first+1, tt.months)
+		}
+	}
+}
+
+func TestTotalMonthsCountsOverlapOnce(t *testing.T) {
+	spans := []span{
+		{first: 100, last: 111},
+		{first: 106, last: 117},
+		{first: 200, last: 200},
+	}
+
+	if got := totalMonths(spans); got != 19 {
+		t.Errorf("total months = %d, want 19", got)
+	}
+}
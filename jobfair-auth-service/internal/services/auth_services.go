@@ -2,22 +2,29 @@ package services
 
 import (
 	"errors"
-	// "time"
 
 	"jobfair-auth-service/internal/models"
 	"jobfair-auth-service/internal/repository"
 	"jobfair-auth-service/internal/utils"
 )
 
+var (
+	ErrEmailNotVerified   = errors.New("email address has not been verified")
+	ErrAccountDeactivated = errors.New("account has been deactivated")
+)
+
 type AuthService struct {
-	userRepo  *repository.UserRepository
-	jwtSecret string
+	userRepo     *repository.UserRepository
+	tokenService *TokenService
+	// requireEmailVerification rejects logins until the email is verified
+	requireEmailVerification bool
 }
 
-func NewAuthService(userRepo *repository.UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo *repository.UserRepository, tokenService *TokenService, requireEmailVerification bool) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:                 userRepo,
+		tokenService:             tokenService,
+		requireEmailVerification: requireEmailVerification,
 	}
 }
 
@@ -44,7 +51,7 @@ func (s *AuthService) Register(req *models.RegisterRequest) (*models.User, error
 	return s.userRepo.Create(user)
 }
 
-func (s *AuthService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
+func (s *AuthService) Login(req *models.LoginRequest, client models.ClientInfo) (*models.LoginResponse, error) {
 	user, err := s.userRepo.GetByEmail(req.Email)
 	if err != nil {
 		return nil, errors.New("invalid credentials")
@@ -54,41 +61,41 @@ func (s *AuthService) Login(req *models.LoginRequest) (*models.LoginResponse, er
 		return nil, errors.New("invalid credentials")
 	}
 
-	token, err := utils.GenerateToken(user.ID, string(user.UserType), s.jwtSecret)
-	if err != nil {
-		return nil, err
+	if !user.IsActive {
+		return nil, ErrAccountDeactivated
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(user.ID, s.jwtSecret)
+	if s.requireEmailVerification && !user.IsEmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	tokens, err := s.tokenService.IssueTokens(user, client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.LoginResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 		User:         *user,
 	}, nil
 }
 
-func (s *AuthService) RefreshToken(refreshToken string) (string, error) {
-	userID, err := utils.ValidateRefreshToken(refreshToken, s.jwtSecret)
-	if err != nil {
-		return "", err
-	}
+// RefreshToken rotates the refresh token and returns a new token pair
+func (s *AuthService) RefreshToken(refreshToken string, client models.ClientInfo) (*TokenPair, error) {
+	return s.tokenService.Rotate(refreshToken, client)
+}
 
-	user, err := s.userRepo.GetByID(userID)
-	if err != nil {
-		return "", err
-	}
+// Logout ends the session the refresh token belongs to
+func (s *AuthService) Logout(userID uint, refreshToken string) error {
+	return s.tokenService.Revoke(userID, refreshToken)
+}
 
-	return utils.GenerateToken(user.ID, string(user.UserType), s.jwtSecret)
+// LogoutAll ends every session of the user
+func (s *AuthService) LogoutAll(userID uint) error {
+	return s.tokenService.RevokeAll(userID, RevokeReasonLogoutAll)
 }
 
 func (s *AuthService) GetUserByID(id uint) (*models.User, error) {
 	return s.userRepo.GetByID(id)
 }
-
-func (s *AuthService) GetAllUsers() ([]models.User, error) {
-	return s.userRepo.GetAll()
-}
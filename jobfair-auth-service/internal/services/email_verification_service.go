@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/repository"
+	"jobfair-auth-service/internal/utils"
+	"jobfair-shared-libs/notification"
+)
+
+// deliveryTimeout bounds sending one email or OTP, so a stuck provider cannot
+// hold the request
+const deliveryTimeout = 20 * time.Second
+
+var (
+	ErrEmailAlreadyVerified      = errors.New("email is already verified")
+	ErrEmailVerificationCooldown = errors.New("please wait before requesting another verification email")
+	ErrInvalidVerificationToken  = errors.New("invalid or expired verification link")
+)
+
+// EmailVerificationSettings controls verification links
+type EmailVerificationSettings struct {
+	// Secret signs the verification tokens
+	Secret string
+	TTL    time.Duration
+	// VerifyURL is the page or endpoint the emailed link points to, the token
+	// is appended as the "token" query parameter
+	VerifyURL      string
+	ResendCooldown time.Duration
+}
+
+type EmailVerificationService struct {
+	userRepo         *repository.UserRepository
+	verificationRepo *repository.EmailVerificationRepository
+	mailer           notification.Mailer
+	settings         EmailVerificationSettings
+}
+
+func NewEmailVerificationService(
+	userRepo *repository.UserRepository,
+	verificationRepo *repository.EmailVerificationRepository,
+	mailer notification.Mailer,
+	settings EmailVerificationSettings,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		mailer:           mailer,
+		settings:         settings,
+	}
+}
+
+// SendVerification issues a new single-use link for the user's current email
+// and supersedes any link sent before it.
+func (s *EmailVerificationService) SendVerification(user *models.User) (*models.EmailVerificationSentData, error) {
+	if user.IsEmailVerified {
+		return nil, ErrEmailAlreadyVerified
+	}
+
+	tokenID, err := utils.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.settings.TTL)
+	token, err := utils.GenerateEmailVerificationToken(user.ID, user.Email, tokenID, s.settings.Secret, s.settings.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verificationRepo.InvalidateForUser(user.ID); err != nil {
+		return nil, err
+	}
+
+	verification := &models.EmailVerification{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.verificationRepo.Create(verification); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+	if err := s.mailer.SendMail(ctx, user.Email, "Verifikasi email JobFair kamu", s.verificationMessage(token)); err != nil {
+		return nil, err
+	}
+
+	return &models.EmailVerificationSentData{
+		Email:     user.Email,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+// ResendVerification sends a fresh link, subject to the resend cooldown
+func (s *EmailVerificationService) ResendVerification(userID uint) (*models.EmailVerificationSentData, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if user.IsEmailVerified {
+		return nil, ErrEmailAlreadyVerified
+	}
+
+	if s.settings.ResendCooldown > 0 {
+		last, err := s.verificationRepo.GetLastSent(userID)
+		if err == nil && time.Since(last.CreatedAt) < s.settings.ResendCooldown {
+			return nil, ErrEmailVerificationCooldown
+		}
+	}
+
+	return s.SendVerification(user)
+}
+
+// VerifyEmail confirms a verification link and marks the user's email as verified
+func (s *EmailVerificationService) VerifyEmail(token string) (*models.EmailVerifiedData, error) {
+	claims, err := utils.ValidateEmailVerificationToken(token, s.settings.Secret)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	verification, err := s.verificationRepo.GetByTokenID(claims.ID)
+	if err != nil || strconv.FormatUint(uint64(verification.UserID), 10) != claims.Subject {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := s.userRepo.GetByID(verification.UserID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	// The link belongs to an address the user no longer has
+	if user.Email != claims.Email || verification.Email != claims.Email {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	if user.IsEmailVerified {
+		return nil, ErrEmailAlreadyVerified
+	}
+
+	used, err := s.verificationRepo.MarkUsed(verification.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	now := time.Now()
+	user.IsEmailVerified = true
+	user.EmailVerifiedAt = &now
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &models.EmailVerifiedData{
+		Email:           user.Email,
+		IsEmailVerified: user.IsEmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+	}, nil
+}
+
+func (s *EmailVerificationService) verificationMessage(token string) string {
+	link := s.settings.VerifyURL + "?token=" + url.QueryEscape(token)
+	return fmt.Sprintf(
+		"Halo,\n\nKlik tautan berikut untuk memverifikasi email akun JobFair kamu:\n\n%s\n\nTautan ini berlaku %d jam dan hanya bisa digunakan sekali. Abaikan email ini jika kamu tidak merasa mendaftar.\n",
+		link, int(s.settings.TTL.Hours()),
+	)
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/repository"
+	"jobfair-auth-service/internal/utils"
+	"jobfair-shared-libs/notification"
+)
+
+const passwordResetOTPPurpose = "password_reset"
+
+var (
+	ErrPasswordResetMethodRequired = errors.New("either email or phone_number is required")
+	ErrInvalidPasswordReset        = errors.New("invalid or expired password reset")
+)
+
+// PasswordResetSettings controls emailed reset links and OTP based resets
+type PasswordResetSettings struct {
+	// Secret signs the reset tokens
+	Secret string
+	TTL    time.Duration
+	// ResetURL is the page the emailed link points to, the token is appended
+	// as the "token" query parameter
+	ResetURL       string
+	ResendCooldown time.Duration
+}
+
+type PasswordResetService struct {
+	userRepo    *repository.UserRepository
+	resetRepo   *repository.PasswordResetRepository
+	otpRepo     *repository.OTPRepository
+	mailer      notification.Mailer
+	otpSender   notification.OTPSender
+	tokens      *TokenService
+	settings    PasswordResetSettings
+	otpSettings OTPSettings
+}
+
+func NewPasswordResetService(
+	userRepo *repository.UserRepository,
+	resetRepo *repository.PasswordResetRepository,
+	otpRepo *repository.OTPRepository,
+	mailer notification.Mailer,
+	otpSender notification.OTPSender,
+	tokens *TokenService,
+	settings PasswordResetSettings,
+	otpSettings OTPSettings,
+) *PasswordResetService {
+	return &PasswordResetService{
+		userRepo:    userRepo,
+		resetRepo:   resetRepo,
+		otpRepo:     otpRepo,
+		mailer:      mailer,
+		otpSender:   otpSender,
+		tokens:      tokens,
+		settings:    settings,
+		otpSettings: otpSettings,
+	}
+}
+
+// RequestReset emails a reset link or sends a reset OTP. Only the account
+// lookup happens before it returns, the link or OTP is created and delivered
+// in the background, so neither the response nor its timing reveals whether
+// an email or phone number is registered. Delivery failures and rate limits
+// are only logged.
+func (s *PasswordResetService) RequestReset(req *models.ForgotPasswordRequest, ipAddress string) error {
+	switch {
+	case req.Email != "":
+		user, err := s.userRepo.GetByEmail(req.Email)
+		if err != nil || !user.IsActive {
+			return nil
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+			defer cancel()
+			if err := s.sendResetEmail(ctx, user, ipAddress); err != nil {
+				log.Printf("Warning: password reset email for user %d not sent: %v", user.ID, err)
+			}
+		}()
+		return nil
+	case req.PhoneNumber != "":
+		user, err := s.userRepo.GetByPhoneNumber(req.PhoneNumber)
+		if err != nil || !user.IsActive {
+			return nil
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+			defer cancel()
+			if err := s.sendResetOTP(ctx, user, req.PhoneNumber); err != nil {
+				log.Printf("Warning: password reset OTP for user %d not sent: %v", user.ID, err)
+			}
+		}()
+		return nil
+	default:
+		return ErrPasswordResetMethodRequired
+	}
+}
+
+// ResetPassword sets a new password using either the emailed token or the
+// phone OTP, then revokes every refresh token of the user.
+func (s *PasswordResetService) ResetPassword(req *models.ResetPasswordRequest) error {
+	var (
+		user *models.User
+		err  error
+	)
+
+	switch {
+	case req.Token != "":
+		user, err = s.consumeResetToken(req.Token)
+	case req.PhoneNumber != "" && req.OTPCode != "":
+		user, err = s.consumeResetOTP(req.PhoneNumber, req.OTPCode)
+	default:
+		return errors.New("either token or phone_number and otp_code are required")
+	}
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.Password = hashedPassword
+	user.PasswordChangedAt = &now
+
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.tokens.RevokeAll(user.ID, RevokeReasonPasswordReset); err != nil {
+		return err
+	}
+
+	// Links requested before the change must not be usable afterwards
+	if err := s.resetRepo.InvalidateForUser(user.ID); err != nil {
+		log.Printf("Warning: failed to invalidate password reset links for user %d: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+func (s *PasswordResetService) sendResetEmail(ctx context.Context, user *models.User, ipAddress string) error {
+	if s.settings.ResendCooldown > 0 {
+		last, err := s.resetRepo.GetLastSent(user.ID)
+		if err == nil && time.Since(last.CreatedAt) < s.settings.ResendCooldown {
+			return errors.New("password reset requested too recently")
+		}
+	}
+
+	tokenID, err := utils.NewTokenID()
+	if err != nil {
+		return err
+	}
+
+	token, err := utils.GeneratePasswordResetToken(user.ID, user.Email, tokenID, s.settings.Secret, s.settings.TTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.resetRepo.InvalidateForUser(user.ID); err != nil {
+		return err
+	}
+
+	reset := &models.PasswordReset{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(s.settings.TTL),
+		IPAddress: ipAddress,
+	}
+	if err := s.resetRepo.Create(reset); err != nil {
+		return err
+	}
+
+	return s.mailer.SendMail(ctx, user.Email, "Reset password JobFair", s.resetMessage(token))
+}
+
+func (s *PasswordResetService) sendResetOTP(ctx context.Context, user *models.User, phoneNumber string) error {
+	if err := checkOTPSendLimits(s.otpRepo, s.otpSettings, phoneNumber, passwordResetOTPPurpose); err != nil {
+		return err
+	}
+
+	otpCode, err := generateOTP()
+	if err != nil {
+		return err
+	}
+	otp := &models.OTPVerification{
+		UserID:      user.ID,
+		PhoneNumber: phoneNumber,
+		OTPHash:     utils.HashOTP(otpCode, s.otpSettings.HashSecret),
+		Purpose:     passwordResetOTPPurpose,
+		ExpiresAt:   time.Now().Add(otpTTL),
+		IsUsed:      false,
+	}
+
+	if err := s.otpRepo.Create(otp); err != nil {
+		return err
+	}
+
+	return s.otpSender.SendOTP(ctx, phoneNumber, otpCode, otpTTL)
+}
+
+func (s *PasswordResetService) consumeResetToken(token string) (*models.User, error) {
+	claims, err := utils.ValidatePasswordResetToken(token, s.settings.Secret)
+	if err != nil {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	reset, err := s.resetRepo.GetByTokenID(claims.ID)
+	if err != nil || strconv.FormatUint(uint64(reset.UserID), 10) != claims.Subject {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	user, err := s.userRepo.GetByID(reset.UserID)
+	if err != nil || user.Email != claims.Email {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	used, err := s.resetRepo.MarkUsed(reset.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	return user, nil
+}
+
+func (s *PasswordResetService) consumeResetOTP(phoneNumber, code string) (*models.User, error) {
+	otp, err := s.otpRepo.GetLatestOTP(phoneNumber, passwordResetOTPPurpose)
+	if err != nil {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	if !utils.CheckOTP(code, otp.OTPHash, s.otpSettings.HashSecret) {
+		remaining, err := s.otpRepo.RecordFailedAttempt(otp.ID, s.otpSettings.MaxAttempts)
+		if err != nil {
+			return nil, err
+		}
+		if remaining == 0 {
+			return nil, errors.New("too many failed attempts, please request a new OTP")
+		}
+		return nil, fmt.Errorf("invalid OTP, %d attempt(s) remaining", remaining)
+	}
+
+	used, err := s.otpRepo.MarkUsed(otp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	user, err := s.userRepo.GetByID(otp.UserID)
+	if err != nil {
+		return nil, ErrInvalidPasswordReset
+	}
+
+	return user, nil
+}
+
+func (s *PasswordResetService) resetMessage(token string) string {
+	link := s.settings.ResetURL + "?token=" + url.QueryEscape(token)
+	return fmt.Sprintf(
+		"Halo,\n\nKami menerima permintaan untuk mengatur ulang password akun JobFair kamu. Klik tautan berikut untuk membuat password baru:\n\n%s\n\nTautan ini berlaku %d menit dan hanya bisa digunakan sekali. Abaikan email ini jika kamu tidak meminta reset password.\n",
+		link, int(s.settings.TTL.Minutes()),
+	)
+}
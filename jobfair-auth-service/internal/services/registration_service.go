@@ -3,17 +3,27 @@ package services
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
+	"io"
+	"log"
+	"math/big"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"jobfair-auth-service/internal/models"
 	"jobfair-auth-service/internal/repository"
 	"jobfair-auth-service/internal/utils"
+	"jobfair-shared-libs/imaging"
+	"jobfair-shared-libs/notification"
+	"jobfair-shared-libs/storage"
 )
 
 type RegistrationService struct {
@@ -21,8 +31,60 @@ type RegistrationService struct {
 	profileRepo        *repository.JobSeekerProfileRepository
 	companyProfileRepo *repository.CompanyBasicProfileRepository
 	otpRepo            *repository.OTPRepository
-	jwtSecret          string
+	otpSender          notification.OTPSender
+	emailVerifier      *EmailVerificationService
+	tokenService       *TokenService
 	companyServiceURL  string
+	store              storage.Storage
+	// exposeOTPCode returns the OTP in the API response, development only
+	exposeOTPCode bool
+	otpTestMode   OTPTestMode
+	otpSettings   OTPSettings
+}
+
+const (
+	otpTTL = 5 * time.Minute
+
+	maxProfilePhotoSize = 5 * 1024 * 1024
+)
+
+var profilePhotoExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".webp": true}
+
+var (
+	ErrOTPResendCooldown = errors.New("please wait before requesting another OTP")
+	ErrOTPDailyLimit     = errors.New("daily OTP limit reached for this phone number")
+)
+
+// OTPSettings controls OTP hashing and abuse limits
+type OTPSettings struct {
+	HashSecret     string
+	MaxAttempts    int
+	ResendCooldown time.Duration
+	DailyLimit     int
+}
+
+// OTPTestMode gives QA a deterministic OTP code. It only applies to the
+// allowlisted phone numbers and every use is written to the audit log.
+type OTPTestMode struct {
+	Enabled      bool
+	Code         string
+	PhoneNumbers []string
+}
+
+func (m OTPTestMode) allows(phoneNumber string) bool {
+	if !m.Enabled || m.Code == "" {
+		return false
+	}
+	for _, allowed := range m.PhoneNumbers {
+		if allowed == phoneNumber {
+			return true
+		}
+	}
+	return false
+}
+
+func auditOTPTestMode(action string, userID uint, phoneNumber string, success bool) {
+	log.Printf("[AUDIT] otp_test_mode action=%s user_id=%d phone=%s success=%t", action, userID, phoneNumber, success)
 }
 
 func NewRegistrationService(
@@ -30,7 +92,13 @@ func NewRegistrationService(
 	profileRepo *repository.JobSeekerProfileRepository,
 	companyProfileRepo *repository.CompanyBasicProfileRepository,
 	otpRepo *repository.OTPRepository,
-	jwtSecret string,
+	otpSender notification.OTPSender,
+	emailVerifier *EmailVerificationService,
+	tokenService *TokenService,
+	exposeOTPCode bool,
+	otpTestMode OTPTestMode,
+	otpSettings OTPSettings,
+	store storage.Storage,
 ) *RegistrationService {
 	companyServiceURL := os.Getenv("COMPANY_SERVICE_URL")
 	if companyServiceURL == "" {
@@ -42,13 +110,19 @@ func NewRegistrationService(
 		profileRepo:        profileRepo,
 		companyProfileRepo: companyProfileRepo,
 		otpRepo:            otpRepo,
-		jwtSecret:          jwtSecret,
+		otpSender:          otpSender,
+		emailVerifier:      emailVerifier,
+		tokenService:       tokenService,
 		companyServiceURL:  companyServiceURL,
+		store:              store,
+		exposeOTPCode:      exposeOTPCode,
+		otpTestMode:        otpTestMode,
+		otpSettings:        otpSettings,
 	}
 }
 
 // Step 1: Initial Registration (Email & Password)
-func (s *RegistrationService) RegisterStep1(req *models.RegisterStep1Request) (*models.RegisterStep1Response, error) {
+func (s *RegistrationService) RegisterStep1(req *models.RegisterStep1Request, client models.ClientInfo) (*models.RegisterStep1Response, error) {
 	existingUser, _ := s.userRepo.GetByEmail(req.Email)
 	if existingUser != nil {
 		return nil, errors.New("email already registered")
@@ -74,12 +148,12 @@ func (s *RegistrationService) RegisterStep1(req *models.RegisterStep1Request) (*
 		return nil, err
 	}
 
-	accessToken, err := utils.GenerateToken(createdUser.ID, string(createdUser.UserType), s.jwtSecret)
-	if err != nil {
-		return nil, err
+	// Registration still succeeds if delivery fails, the user can ask for a resend
+	if _, err := s.emailVerifier.SendVerification(createdUser); err != nil {
+		fmt.Printf("Warning: Failed to send verification email to %s: %v\n", createdUser.Email, err)
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(createdUser.ID, s.jwtSecret)
+	tokens, err := s.tokenService.IssueTokens(createdUser, client)
 	if err != nil {
 		return nil, err
 	}
@@ -89,8 +163,8 @@ func (s *RegistrationService) RegisterStep1(req *models.RegisterStep1Request) (*
 		Email:        createdUser.Email,
 		UserType:     createdUser.UserType,
 		NextStep:     "complete_profile",
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
 
@@ -202,13 +276,28 @@ func (s *RegistrationService) SendPhoneOTP(userID uint, req *models.PhoneVerific
 		return nil, errors.New("user not found")
 	}
 
-	otpCode := s.generateOTP()
+	// Nomor QA di test mode memakai kode tetap dan tidak dikirim ke provider
+	testMode := s.otpTestMode.allows(req.PhoneNumber)
+
+	if !testMode {
+		if err := checkOTPSendLimits(s.otpRepo, s.otpSettings, req.PhoneNumber, "phone_verification"); err != nil {
+			return nil, err
+		}
+	}
+
+	otpCode, err := generateOTP()
+	if err != nil {
+		return nil, err
+	}
+	if testMode {
+		otpCode = s.otpTestMode.Code
+	}
 	otp := &models.OTPVerification{
 		UserID:      userID,
 		PhoneNumber: req.PhoneNumber,
-		OTPCode:     otpCode,
+		OTPHash:     utils.HashOTP(otpCode, s.otpSettings.HashSecret),
 		Purpose:     "phone_verification",
-		ExpiresAt:   time.Now().Add(5 * time.Minute),
+		ExpiresAt:   time.Now().Add(otpTTL),
 		IsUsed:      false,
 	}
 
@@ -216,71 +305,62 @@ func (s *RegistrationService) SendPhoneOTP(userID uint, req *models.PhoneVerific
 		return nil, err
 	}
 
-	fmt.Printf("OTP for %s: %s\n", req.PhoneNumber, otpCode)
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+	if testMode {
+		auditOTPTestMode("send", userID, req.PhoneNumber, true)
+	} else if err := s.otpSender.SendOTP(ctx, req.PhoneNumber, otpCode, otpTTL); err != nil {
+		fmt.Printf("Warning: Failed to send OTP to %s: %v\n", req.PhoneNumber, err)
+		return nil, errors.New("failed to send OTP, please try again")
+	}
 
-	return &models.OTPSentData{
+	data := &models.OTPSentData{
 		PhoneNumber: req.PhoneNumber,
-		OTPCode:     otpCode,
 		ExpiresAt:   otp.ExpiresAt.Unix(),
-	}, nil
+	}
+	if s.exposeOTPCode {
+		data.OTPCode = otpCode
+	}
+
+	return data, nil
 }
 
 // Step 4: Verify OTP
 func (s *RegistrationService) VerifyPhoneOTP(req *models.VerifyOTPRequest) (*models.BasicProfileData, error) {
-	if req.OTPCode == "123456" {
-		user, err := s.userRepo.GetByPhoneNumber(req.PhoneNumber)
-		if err != nil {
-			return nil, errors.New("user not found")
-		}
+	testMode := s.otpTestMode.allows(req.PhoneNumber)
 
-		now := time.Now()
-		user.IsPhoneVerified = true
-		user.PhoneVerifiedAt = &now
+	otp, err := s.otpRepo.GetLatestOTP(req.PhoneNumber, "phone_verification")
+	if err != nil {
+		if testMode {
+			auditOTPTestMode("verify", 0, req.PhoneNumber, false)
+		}
+		return nil, errors.New("invalid or expired OTP")
+	}
 
-		if err := s.userRepo.Update(user); err != nil {
+	if !utils.CheckOTP(req.OTPCode, otp.OTPHash, s.otpSettings.HashSecret) {
+		if testMode {
+			auditOTPTestMode("verify", otp.UserID, req.PhoneNumber, false)
+		}
+		remaining, err := s.otpRepo.RecordFailedAttempt(otp.ID, s.otpSettings.MaxAttempts)
+		if err != nil {
 			return nil, err
 		}
-
-		// Return appropriate data based on user type
-		if user.UserType == models.UserTypeCompany {
-			companyProfile, _ := s.companyProfileRepo.GetByUserID(user.ID)
-			if companyProfile != nil {
-				return &models.BasicProfileData{
-					CompanyName: companyProfile.CompanyName,
-					Industry:    companyProfile.Industry,
-					PhoneNumber: user.PhoneNumber,
-					CountryCode: user.CountryCode,
-					Address:     companyProfile.Address,
-					Website:     companyProfile.Website,
-				}, nil
-			}
+		if remaining == 0 {
+			return nil, errors.New("too many failed attempts, please request a new OTP")
 		}
-
-		return &models.BasicProfileData{
-			FirstName:   user.FirstName,
-			LastName:    user.LastName,
-			PhoneNumber: user.PhoneNumber,
-			CountryCode: user.CountryCode,
-			Country:     user.Country,
-		}, nil
+		return nil, fmt.Errorf("invalid OTP, %d attempt(s) remaining", remaining)
 	}
 
-	otp, err := s.otpRepo.GetLatestOTP(req.PhoneNumber, "phone_verification", req.OTPCode)
+	used, err := s.otpRepo.MarkUsed(otp.ID)
 	if err != nil {
-		return nil, errors.New("invalid or expired OTP")
-	}
-
-	if time.Now().After(otp.ExpiresAt) {
-		return nil, errors.New("OTP has expired")
+		return nil, err
 	}
-
-	if otp.IsUsed {
+	if !used {
 		return nil, errors.New("OTP has already been used")
 	}
 
-	otp.IsUsed = true
-	if err := s.otpRepo.Update(otp); err != nil {
-		return nil, err
+	if testMode {
+		auditOTPTestMode("verify", otp.UserID, req.PhoneNumber, true)
 	}
 
 	user, err := s.userRepo.GetByPhoneNumber(otp.PhoneNumber)
@@ -404,12 +484,43 @@ func (s *RegistrationService) SetPermissions(userID uint, req *models.Permission
 }
 
 // Step 8: Upload Profile Photo/Logo (Unified)
-func (s *RegistrationService) UploadProfilePhoto(userID uint, photoURL string) (*models.ProfilePhotoData, error) {
+func (s *RegistrationService) UploadProfilePhoto(userID uint, file *multipart.FileHeader) (*models.ProfilePhotoData, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
 
+	if file.Size > maxProfilePhotoSize {
+		return nil, errors.New("file size too large (max 5MB)")
+	}
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !profilePhotoExtensions[ext] {
+		return nil, errors.New("invalid file type, allowed: jpg, jpeg, png, webp")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The extension is only a claim, the bytes decide what the file is
+	if _, err := imaging.CheckFormat(data, ext); err != nil {
+		return nil, err
+	}
+
+	keys, err := imaging.Store(context.Background(), s.store, fmt.Sprintf("users/%d/photo", userID), data)
+	if err != nil {
+		return nil, err
+	}
+	variants := imaging.VariantURLs(keys)
+	photoURL := variants[imaging.VariantOriginal]
+
+	replaced := user.ProfilePhoto
 	user.ProfilePhoto = photoURL
 	user.IsProfileComplete = true
 
@@ -417,6 +528,18 @@ func (s *RegistrationService) UploadProfilePhoto(userID uint, photoURL string) (
 		return nil, err
 	}
 
+	// A company logo is copied to the company service, which keeps pointing at
+	// the old file, so only job seeker photos are cleaned up
+	if user.UserType == models.UserTypeJobSeeker && replaced != "" && replaced != photoURL {
+		if key, ok := storage.KeyFromURL(replaced); ok {
+			for _, variantKey := range imaging.VariantKeys(key) {
+				if err := s.store.Delete(context.Background(), variantKey); err != nil {
+					log.Printf("Warning: failed to delete replaced profile photo %s: %v", variantKey, err)
+				}
+			}
+		}
+	}
+
 	// If company, also update company_basic_profiles
 	if user.UserType == models.UserTypeCompany {
 		companyProfile, _ := s.companyProfileRepo.GetByUserID(userID)
@@ -431,7 +554,7 @@ func (s *RegistrationService) UploadProfilePhoto(userID uint, photoURL string) (
 		}
 	}
 
-	return &models.ProfilePhotoData{PhotoURL: photoURL}, nil
+	return &models.ProfilePhotoData{PhotoURL: photoURL, Variants: variants}, nil
 }
 
 // Helper: Create company record in company service
@@ -481,10 +604,36 @@ func (s *RegistrationService) createCompanyInCompanyService(user *models.User, p
 	return nil
 }
 
-// Helper: Generate OTP
-func (s *RegistrationService) generateOTP() string {
-	rand.Seed(time.Now().UnixNano())
-	return fmt.Sprintf("%06d", rand.Intn(1000000))
+// Helper: Generate OTP, the code guards phone verification and password
+// resets so it must not be predictable
+func generateOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// Helper: enforce resend cooldown and daily cap per phone number and purpose
+func checkOTPSendLimits(otpRepo *repository.OTPRepository, settings OTPSettings, phoneNumber, purpose string) error {
+	if settings.ResendCooldown > 0 {
+		last, err := otpRepo.GetLastSent(phoneNumber, purpose)
+		if err == nil && time.Since(last.CreatedAt) < settings.ResendCooldown {
+			return ErrOTPResendCooldown
+		}
+	}
+
+	if settings.DailyLimit > 0 {
+		count, err := otpRepo.CountSentSince(phoneNumber, purpose, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			return err
+		}
+		if count >= int64(settings.DailyLimit) {
+			return ErrOTPDailyLimit
+		}
+	}
+
+	return nil
 }
 
 // Helper: Get User By ID
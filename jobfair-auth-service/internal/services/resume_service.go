@@ -0,0 +1,352 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/repository"
+	"jobfair-auth-service/internal/resumeparser"
+	"jobfair-auth-service/internal/utils"
+	"jobfair-shared-libs/storage"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	maxResumeSize = 5 * 1024 * 1024
+	maxResumes    = 10
+)
+
+// resumeMIMETypes lists the content types the sniffed bytes of each allowed
+// extension may have
+var resumeMIMETypes = map[string][]string{
+	".pdf": {"application/pdf"},
+	// Older Word files are only recognised as OLE containers
+	".doc":  {"application/msword", "application/x-ole-storage"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+}
+
+var (
+	ErrResumeNotFound      = errors.New("resume not found")
+	ErrResumeLimitReached  = repository.ErrResumeLimitReached
+	ErrInvalidResumeFile   = errors.New("invalid file type, allowed: pdf, doc, docx")
+	ErrResumeTooLarge      = errors.New("file size too large (max 5MB)")
+	ErrResumeTypeMismatch  = errors.New("file content does not match its extension")
+	ErrInvalidDownloadLink = errors.New("invalid or expired download link")
+)
+
+// ResumeSettings controls the download links handed out for resumes
+type ResumeSettings struct {
+	// Secret signs the download tokens
+	Secret string
+	// DownloadURL is the endpoint serving resume files, the token is
+	// appended as the "token" query parameter
+	DownloadURL string
+	LinkTTL     time.Duration
+}
+
+// ResumeService manages the resume library of job seekers. Files are never
+// public, they are only reachable through short-lived download links.
+type ResumeService struct {
+	resumeRepo *repository.ResumeRepository
+	store      storage.Storage
+	settings   ResumeSettings
+}
+
+func NewResumeService(resumeRepo *repository.ResumeRepository, store storage.Storage, settings ResumeSettings) *ResumeService {
+	return &ResumeService{
+		resumeRepo: resumeRepo,
+		store:      store,
+		settings:   settings,
+	}
+}
+
+func (s *ResumeService) ListResumes(userID uint) ([]*models.Resume, error) {
+	return s.resumeRepo.ListByUser(userID)
+}
+
+// UploadResume adds a file to the library. The first resume becomes the
+// default.
+func (s *ResumeService) UploadResume(userID uint, file *multipart.FileHeader, req *models.UploadResumeRequest) (*models.Resume, error) {
+	resume, err := s.storeFile(userID, file)
+	if err != nil {
+		return nil, err
+	}
+	if name := strings.TrimSpace(req.Name); name != "" {
+		resume.Name = name
+	}
+	resume.IsDefault = req.IsDefault
+
+	// The file is kept even if the limit is hit, the same bytes may already
+	// back another resume or an application
+	if err := s.resumeRepo.CreateWithinLimit(resume, maxResumes); err != nil {
+		return nil, err
+	}
+
+	return resume, nil
+}
+
+// UploadApplicationResume stores a file a job seeker uploaded with an
+// application instead of picking one from the library. It is kept out of the
+// library and does not count towards its limit.
+func (s *ResumeService) UploadApplicationResume(userID uint, file *multipart.FileHeader) (*models.Resume, error) {
+	resume, err := s.storeFile(userID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resumeRepo.CreateDetached(resume); err != nil {
+		return nil, err
+	}
+
+	return resume, nil
+}
+
+// DiscardApplicationResume removes a resume created by
+// UploadApplicationResume when the application could not be saved. The file
+// is only deleted when no other resume has the same content.
+func (s *ResumeService) DiscardApplicationResume(ctx context.Context, userID, resumeID uint) error {
+	resume, err := s.resumeRepo.GetByUserUnscoped(userID, resumeID)
+	if err != nil || !resume.DeletedAt.Valid {
+		return ErrResumeNotFound
+	}
+
+	if err := s.resumeRepo.Purge(resume); err != nil {
+		return err
+	}
+
+	inUse, err := s.resumeRepo.FileKeyInUse(resume.FileKey)
+	if err != nil || inUse {
+		return err
+	}
+	return s.store.Delete(ctx, resume.FileKey)
+}
+
+// storeFile validates and stores an uploaded resume file and returns the
+// unsaved resume record for it
+func (s *ResumeService) storeFile(userID uint, file *multipart.FileHeader) (*models.Resume, error) {
+	if file.Size > maxResumeSize {
+		return nil, ErrResumeTooLarge
+	}
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	allowed, ok := resumeMIMETypes[ext]
+	if !ok {
+		return nil, ErrInvalidResumeFile
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The extension is only a claim, the bytes decide what the file is
+	detected := mimetype.Detect(data)
+	if !containsMIME(detected, allowed) {
+		return nil, ErrResumeTypeMismatch
+	}
+
+	key, err := storage.PutContentAddressed(context.Background(), s.store, fmt.Sprintf("resumes/%d", userID), ext, bytes.NewReader(data), detected.String())
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := filepath.Base(file.Filename)
+	resume := &models.Resume{
+		UserID:   userID,
+		Name:     strings.TrimSuffix(fileName, filepath.Ext(fileName)),
+		FileName: fileName,
+		FileKey:  key,
+		FileSize: file.Size,
+		MimeType: detected.String(),
+	}
+	resume.ParseStatus, resume.Parsed = parseResume(data, ext)
+
+	return resume, nil
+}
+
+// UpdateResume renames a resume or makes it the default, fields left nil are
+// kept
+func (s *ResumeService) UpdateResume(userID, resumeID uint, req *models.UpdateResumeRequest) (*models.Resume, error) {
+	resume, err := s.resumeRepo.GetByUser(userID, resumeID)
+	if err != nil {
+		return nil, ErrResumeNotFound
+	}
+
+	if req.Name != nil {
+		resume.Name = strings.TrimSpace(*req.Name)
+		if err := s.resumeRepo.UpdateName(resume); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsDefault != nil && *req.IsDefault && !resume.IsDefault {
+		if err := s.resumeRepo.SetDefault(resume); err != nil {
+			return nil, err
+		}
+	}
+
+	return resume, nil
+}
+
+// DeleteResume removes a resume from the library. The file stays stored for
+// applications that were submitted with it.
+func (s *ResumeService) DeleteResume(userID, resumeID uint) error {
+	resume, err := s.resumeRepo.GetByUser(userID, resumeID)
+	if err != nil {
+		return ErrResumeNotFound
+	}
+
+	return s.resumeRepo.Delete(resume)
+}
+
+// DownloadLink returns a link to a resume of the user's own library
+func (s *ResumeService) DownloadLink(userID, resumeID uint) (*models.ResumeDownloadLink, error) {
+	resume, err := s.resumeRepo.GetByUser(userID, resumeID)
+	if err != nil {
+		return nil, ErrResumeNotFound
+	}
+
+	return s.downloadLink(resume)
+}
+
+// ApplicationDownloadLink returns a link for the company service. The caller
+// has checked that the resume belongs to an application to the company's
+// job, so resumes removed from the library are included.
+func (s *ResumeService) ApplicationDownloadLink(userID, resumeID uint) (*models.ResumeDownloadLink, error) {
+	resume, err := s.resumeRepo.GetByUserUnscoped(userID, resumeID)
+	if err != nil {
+		return nil, ErrResumeNotFound
+	}
+
+	return s.downloadLink(resume)
+}
+
+// ResolveResume returns the resume a job seeker applies with, resumeID 0
+// picks the default resume
+func (s *ResumeService) ResolveResume(userID, resumeID uint) (*models.Resume, error) {
+	var resume *models.Resume
+	var err error
+	if resumeID == 0 {
+		resume, err = s.resumeRepo.GetDefault(userID)
+	} else {
+		resume, err = s.resumeRepo.GetByUser(userID, resumeID)
+	}
+	if err != nil {
+		return nil, ErrResumeNotFound
+	}
+
+	// Resumes uploaded before parsing existed are parsed on first use
+	if resume.ParseStatus == models.ResumeParsePending {
+		s.parseStored(resume)
+	}
+
+	return resume, nil
+}
+
+// OpenDownload resolves a download token. Backends that can sign URLs return
+// a short-lived direct URL, otherwise the file is opened and the caller
+// closes the reader.
+func (s *ResumeService) OpenDownload(ctx context.Context, token string) (*models.Resume, string, io.ReadCloser, error) {
+	resumeID, err := utils.ValidateResumeDownloadToken(token, s.settings.Secret)
+	if err != nil {
+		return nil, "", nil, ErrInvalidDownloadLink
+	}
+
+	resume, err := s.resumeRepo.GetByIDUnscoped(resumeID)
+	if err != nil {
+		return nil, "", nil, ErrResumeNotFound
+	}
+
+	if signer, ok := s.store.(storage.URLSigner); ok {
+		signedURL, err := signer.SignedURL(ctx, resume.FileKey, s.settings.LinkTTL)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		return resume, signedURL, nil, nil
+	}
+
+	reader, _, err := s.store.Get(ctx, resume.FileKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", nil, ErrResumeNotFound
+	}
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return resume, "", reader, nil
+}
+
+func (s *ResumeService) downloadLink(resume *models.Resume) (*models.ResumeDownloadLink, error) {
+	token, err := utils.GenerateResumeDownloadToken(resume.ID, s.settings.Secret, s.settings.LinkTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ResumeDownloadLink{
+		URL:       s.settings.DownloadURL + "?token=" + url.QueryEscape(token),
+		FileName:  resume.FileName,
+		ExpiresAt: time.Now().Add(s.settings.LinkTTL).Unix(),
+	}, nil
+}
+
+// parseStored parses a resume from its stored file. Failures are logged, the
+// resume stays usable without a parsed record.
+func (s *ResumeService) parseStored(resume *models.Resume) {
+	reader, _, err := s.store.Get(context.Background(), resume.FileKey)
+	if err != nil {
+		log.Printf("Warning: failed to read resume %d for parsing: %v", resume.ID, err)
+		return
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		log.Printf("Warning: failed to read resume %d for parsing: %v", resume.ID, err)
+		return
+	}
+
+	resume.ParseStatus, resume.Parsed = parseResume(data, filepath.Ext(resume.FileKey))
+	if err := s.resumeRepo.UpdateParsed(resume); err != nil {
+		log.Printf("Warning: failed to save parsed resume %d: %v", resume.ID, err)
+	}
+}
+
+// parseResume extracts contact info, skills, education and work history from
+// the file text. A file that cannot be parsed is still a valid resume.
+func parseResume(data []byte, ext string) (models.ResumeParseStatus, *models.ParsedResume) {
+	text, err := resumeparser.ExtractText(data, ext)
+	if errors.Is(err, resumeparser.ErrUnsupportedFormat) {
+		return models.ResumeParseUnsupported, nil
+	}
+	if err != nil {
+		log.Printf("Warning: failed to extract resume text: %v", err)
+		return models.ResumeParseFailed, nil
+	}
+	// Scanned documents have no text layer
+	if strings.TrimSpace(text) == "" {
+		return models.ResumeParseFailed, nil
+	}
+
+	return models.ResumeParseParsed, resumeparser.Parse(text, time.Now())
+}
+
+func containsMIME(detected *mimetype.MIME, types []string) bool {
+	for _, t := range types {
+		if detected.Is(t) {
+			return true
+		}
+	}
+	return false
+}
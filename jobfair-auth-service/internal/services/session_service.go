@@ -0,0 +1,99 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/repository"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionService exposes refresh token families as user visible sessions.
+// The session ID is the family ID, which is also the sid claim of every
+// access token issued for that session.
+type SessionService struct {
+	refreshTokenRepo *repository.RefreshTokenRepository
+}
+
+func NewSessionService(refreshTokenRepo *repository.RefreshTokenRepository) *SessionService {
+	return &SessionService{refreshTokenRepo: refreshTokenRepo}
+}
+
+// ListSessions returns the active sessions of a user. currentSessionID marks
+// the session the request was made from.
+func (s *SessionService) ListSessions(userID uint, currentSessionID string) ([]models.SessionData, error) {
+	tokens, err := s.refreshTokenRepo.ListActive(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := map[string]time.Time{}
+	if len(tokens) > 0 {
+		familyIDs := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			familyIDs = append(familyIDs, token.FamilyID)
+		}
+
+		startedAt, err = s.refreshTokenRepo.SessionStartTimes(familyIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sessions := make([]models.SessionData, 0, len(tokens))
+	for _, token := range tokens {
+		createdAt, ok := startedAt[token.FamilyID]
+		if !ok {
+			createdAt = token.CreatedAt
+		}
+
+		sessions = append(sessions, models.SessionData{
+			ID:         token.FamilyID,
+			DeviceName: deviceName(token.DeviceInfo),
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			CreatedAt:  createdAt,
+			// A new refresh token is issued on every refresh
+			LastUsedAt: token.CreatedAt,
+			ExpiresAt:  token.ExpiresAt,
+			Current:    token.FamilyID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends one session of the user
+func (s *SessionService) RevokeSession(userID uint, sessionID string) error {
+	revoked, err := s.refreshTokenRepo.RevokeUserFamily(userID, sessionID, RevokeReasonSessionRevoke)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// IsSessionActive is used by other services to reject access tokens whose
+// session was revoked before the token expired
+func (s *SessionService) IsSessionActive(sessionID string) (bool, error) {
+	return s.refreshTokenRepo.IsFamilyActive(sessionID)
+}
+
+func deviceName(deviceInfo *string) string {
+	if deviceInfo == nil {
+		return ""
+	}
+
+	var info struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(*deviceInfo), &info); err != nil {
+		return ""
+	}
+	return info.Name
+}
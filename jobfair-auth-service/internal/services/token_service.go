@@ -0,0 +1,173 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"jobfair-auth-service/internal/models"
+	"jobfair-auth-service/internal/repository"
+	"jobfair-auth-service/internal/utils"
+)
+
+const refreshTokenTTL = 7 * 24 * time.Hour
+
+// Reasons stored in refresh_tokens.revoked_reason
+const (
+	RevokeReasonRotated       = "rotated"
+	RevokeReasonLogout        = "logout"
+	RevokeReasonLogoutAll     = "logout_all"
+	RevokeReasonReuseDetected = "reuse_detected"
+	RevokeReasonPasswordReset = "password_reset"
+	RevokeReasonSessionRevoke = "session_revoked"
+	RevokeReasonDeactivated   = "deactivated"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, please log in again")
+)
+
+// TokenPair is handed to the client after login, registration and refresh
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// TokenService issues access tokens and manages the stored refresh tokens.
+// Every refresh rotates the token; presenting an already rotated token means
+// it leaked, so the whole family descending from that login is revoked.
+type TokenService struct {
+	userRepo         *repository.UserRepository
+	refreshTokenRepo *repository.RefreshTokenRepository
+	signingKeys      *utils.KeySet
+}
+
+func NewTokenService(userRepo *repository.UserRepository, refreshTokenRepo *repository.RefreshTokenRepository, signingKeys *utils.KeySet) *TokenService {
+	return &TokenService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		signingKeys:      signingKeys,
+	}
+}
+
+// IssueTokens starts a new session (refresh token family) for the user
+func (s *TokenService) IssueTokens(user *models.User, client models.ClientInfo) (*TokenPair, error) {
+	familyID, err := utils.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, record, err := s.newRefreshToken(user.ID, familyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.Create(record); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := utils.GenerateToken(user.ID, string(user.UserType), familyID, s.signingKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// Rotate exchanges a refresh token for a new pair
+func (s *TokenService) Rotate(refreshToken string, client models.ClientInfo) (*TokenPair, error) {
+	current, err := s.refreshTokenRepo.GetByHash(utils.HashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if current.IsRevoked {
+		if current.RevokedReason == RevokeReasonRotated {
+			s.revokeReusedFamily(current)
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(current.UserID)
+	if err != nil || !user.IsActive {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	nextToken, next, err := s.newRefreshToken(current.UserID, current.FamilyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.refreshTokenRepo.Rotate(current, next)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// A concurrent request rotated this token first
+		s.revokeReusedFamily(current)
+		return nil, ErrRefreshTokenReused
+	}
+
+	accessToken, err := utils.GenerateToken(user.ID, string(user.UserType), current.FamilyID, s.signingKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{AccessToken: accessToken, RefreshToken: nextToken}, nil
+}
+
+// Revoke ends the session the refresh token belongs to
+func (s *TokenService) Revoke(userID uint, refreshToken string) error {
+	current, err := s.refreshTokenRepo.GetByHash(utils.HashRefreshToken(refreshToken))
+	if err != nil || current.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+
+	return s.refreshTokenRepo.RevokeFamily(current.FamilyID, RevokeReasonLogout)
+}
+
+// RevokeAll ends every session of the user
+func (s *TokenService) RevokeAll(userID uint, reason string) error {
+	return s.refreshTokenRepo.RevokeAllForUser(userID, reason)
+}
+
+func (s *TokenService) revokeReusedFamily(token *models.RefreshToken) {
+	log.Printf("[SECURITY] refresh token reuse detected user_id=%d family=%s", token.UserID, token.FamilyID)
+	if err := s.refreshTokenRepo.RevokeFamily(token.FamilyID, RevokeReasonReuseDetected); err != nil {
+		log.Printf("Failed to revoke refresh token family %s: %v", token.FamilyID, err)
+	}
+}
+
+func (s *TokenService) newRefreshToken(userID uint, familyID string, client models.ClientInfo) (string, *models.RefreshToken, error) {
+	token, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := &models.RefreshToken{
+		UserID:    userID,
+		TokenHash: utils.HashRefreshToken(token),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+	}
+
+	if client.DeviceName != "" {
+		deviceInfo, err := json.Marshal(map[string]string{"name": client.DeviceName})
+		if err != nil {
+			return "", nil, err
+		}
+		info := string(deviceInfo)
+		record.DeviceInfo = &info
+	}
+
+	return token, record, nil
+}
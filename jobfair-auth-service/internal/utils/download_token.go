@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const resumeDownloadAudience = "resume_download"
+
+// GenerateResumeDownloadToken signs a link token that allows downloading one
+// resume until it expires. It is not single-use, a link shared within its
+// lifetime keeps working, so keep the TTL short.
+func GenerateResumeDownloadToken(resumeID uint, secret string, ttl time.Duration) (string, error) {
+	claims := jwt.RegisteredClaims{
+		Subject:   strconv.FormatUint(uint64(resumeID), 10),
+		Audience:  jwt.ClaimStrings{resumeDownloadAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ValidateResumeDownloadToken returns the resume ID a token grants access to
+func ValidateResumeDownloadToken(tokenString, secret string) (uint, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience(resumeDownloadAudience))
+
+	if err != nil {
+		return 0, err
+	}
+
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || !token.Valid {
+		return 0, errors.New("invalid token")
+	}
+
+	resumeID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || resumeID == 0 {
+		return 0, errors.New("invalid token")
+	}
+
+	return uint(resumeID), nil
+}
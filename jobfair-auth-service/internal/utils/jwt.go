@@ -2,7 +2,6 @@ package utils
 
 import (
     "errors"
-    "strconv"
     "time"
 
     "github.com/golang-jwt/jwt/v5"
@@ -11,38 +10,47 @@ import (
 type Claims struct {
     UserID   uint   `json:"user_id"`
     UserType string `json:"user_type"`
+    // SessionID is the refresh token family the access token was issued for,
+    // services use it to reject tokens of revoked sessions
+    SessionID string `json:"sid,omitempty"`
     jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint, userType, secret string) (string, error) {
+// GenerateToken signs an access token with the active key of the set. The kid
+// header tells verifiers which JWKS entry to use.
+func GenerateToken(userID uint, userType, sessionID string, keys *KeySet) (string, error) {
     claims := Claims{
-        UserID:   userID,
-        UserType: userType,
+        UserID:    userID,
+        UserType:  userType,
+        SessionID: sessionID,
         RegisteredClaims: jwt.RegisteredClaims{
             ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
             IssuedAt:  jwt.NewNumericDate(time.Now()),
         },
     }
 
-    token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    return token.SignedString([]byte(secret))
+    signingKey := keys.Active()
+    token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+    token.Header["kid"] = signingKey.ID
+    return token.SignedString(signingKey.PrivateKey)
 }
 
-func GenerateRefreshToken(userID uint, secret string) (string, error) {
-    claims := jwt.RegisteredClaims{
-        Subject:   strconv.FormatUint(uint64(userID), 10),
-        ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-        IssuedAt:  jwt.NewNumericDate(time.Now()),
+// KeyFunc resolves the verification key of an RS256 token from its kid header.
+func (ks *KeySet) KeyFunc(token *jwt.Token) (interface{}, error) {
+    if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+        return nil, jwt.ErrSignatureInvalid
     }
 
-    token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    return token.SignedString([]byte(secret))
+    kid, _ := token.Header["kid"].(string)
+    publicKey, ok := ks.PublicKey(kid)
+    if !ok {
+        return nil, errors.New("unknown signing key")
+    }
+    return publicKey, nil
 }
 
-func ValidateToken(tokenString, secret string) (*Claims, error) {
-    token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-        return []byte(secret), nil
-    })
+func ValidateToken(tokenString string, keys *KeySet) (*Claims, error) {
+    token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys.KeyFunc)
 
     if err != nil {
         return nil, err
@@ -54,26 +62,4 @@ func ValidateToken(tokenString, secret string) (*Claims, error) {
     }
 
     return claims, nil
-}
-
-func ValidateRefreshToken(tokenString, secret string) (uint, error) {
-    token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-        return []byte(secret), nil
-    })
-
-    if err != nil {
-        return 0, err
-    }
-
-    claims, ok := token.Claims.(*jwt.RegisteredClaims)
-    if !ok || !token.Valid {
-        return 0, errors.New("invalid token")
-    }
-
-    userID, err := strconv.ParseUint(claims.Subject, 10, 32)
-    if err != nil {
-        return 0, err
-    }
-
-    return uint(userID), nil
 }
\ No newline at end of file
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SigningKey is an RSA key identified by the kid header of the tokens it signs.
+type SigningKey struct {
+	ID         string
+	PrivateKey *rsa.PrivateKey
+}
+
+// KeySet holds the key used to sign new access tokens plus every key whose
+// tokens may still be in circulation. Rotation works by adding a new key,
+// making it active, and removing the old one only after the access token TTL
+// has passed, so both are published in the JWKS during the overlap.
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// LoadKeySet reads every <kid>.pem RSA private key (PKCS#1 or PKCS#8) in dir.
+// activeKID selects the signing key; when empty the last kid in lexical
+// order is used, so date prefixed names rotate naturally.
+func LoadKeySet(dir, activeKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *.pem signing keys found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	ks := &KeySet{keys: make(map[string]*SigningKey)}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := parseRSAPrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		ks.keys[kid] = &SigningKey{ID: kid, PrivateKey: privateKey}
+		if activeKID == "" {
+			ks.active = ks.keys[kid]
+		}
+	}
+
+	if activeKID != "" {
+		active, ok := ks.keys[activeKID]
+		if !ok {
+			return nil, fmt.Errorf("active signing key %q not found in %s", activeKID, dir)
+		}
+		ks.active = active
+	}
+
+	return ks, nil
+}
+
+// GenerateKeySet creates a single in-memory key. Tokens signed with it become
+// invalid on restart, so it is only meant for local development.
+func GenerateKeySet() (*KeySet, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: keyThumbprint(&privateKey.PublicKey), PrivateKey: privateKey}
+	return &KeySet{active: key, keys: map[string]*SigningKey{key.ID: key}}, nil
+}
+
+// Active returns the key new tokens are signed with.
+func (ks *KeySet) Active() *SigningKey {
+	return ks.active
+}
+
+// PublicKey returns the verification key for a kid.
+func (ks *KeySet) PublicKey(kid string) (*rsa.PublicKey, bool) {
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, false
+	}
+	return &key.PrivateKey.PublicKey, true
+}
+
+// JWK is the public part of a signing key as published in the JWKS.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS returns every public key of the set, sorted by kid.
+func (ks *KeySet) JWKS() []JWK {
+	kids := make([]string, 0, len(ks.keys))
+	for kid := range ks.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	jwks := make([]JWK, 0, len(kids))
+	for _, kid := range kids {
+		publicKey := ks.keys[kid].PrivateKey.PublicKey
+		jwks = append(jwks, JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		})
+	}
+	return jwks
+}
+
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an RSA key")
+	}
+	return key, nil
+}
+
+func keyThumbprint(publicKey *rsa.PublicKey) string {
+	sum := sha256.Sum256(x509.MarshalPKCS1PublicKey(publicKey))
+	return hex.EncodeToString(sum[:8])
+}
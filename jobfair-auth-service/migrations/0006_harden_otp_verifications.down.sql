@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_otp_verifications_phone_created;
+
+-- Hashed codes cannot be converted back to plaintext
+DELETE FROM otp_verifications;
+
+ALTER TABLE otp_verifications DROP COLUMN IF EXISTS attempts;
+ALTER TABLE otp_verifications ALTER COLUMN otp_hash TYPE VARCHAR(6);
+ALTER TABLE otp_verifications RENAME COLUMN otp_hash TO otp_code;
\ No newline at end of file
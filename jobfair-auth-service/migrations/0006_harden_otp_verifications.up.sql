@@ -0,0 +1,15 @@
+-- Store OTP codes as HMAC hashes and track failed attempts
+-- Existing plaintext codes cannot be verified anymore, expire them
+UPDATE otp_verifications SET is_used = TRUE, used_at = CURRENT_TIMESTAMP WHERE is_used = FALSE;
+
+ALTER TABLE otp_verifications RENAME COLUMN otp_code TO otp_hash;
+ALTER TABLE otp_verifications ALTER COLUMN otp_hash TYPE VARCHAR(64);
+ALTER TABLE otp_verifications ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+-- Index for resend cooldown and daily cap lookups
+CREATE INDEX IF NOT EXISTS idx_otp_verifications_phone_created
+ON otp_verifications(phone_number, purpose, created_at);
+
+-- Comments
+COMMENT ON COLUMN otp_verifications.otp_hash IS 'HMAC-SHA256 of the OTP code (hex)';
+COMMENT ON COLUMN otp_verifications.attempts IS 'Failed verification attempts, OTP is invalidated after the configured maximum';
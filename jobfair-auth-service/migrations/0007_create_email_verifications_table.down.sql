@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_email_verifications_user_id;
+DROP INDEX IF EXISTS idx_email_verifications_expires_at;
+
+-- Drop table
+DROP TABLE IF EXISTS email_verifications;
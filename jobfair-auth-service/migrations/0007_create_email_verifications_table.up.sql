@@ -0,0 +1,30 @@
+-- Create email_verifications table for single-use email verification links
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    token_id VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_email_verifications_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    -- Unique constraint
+    CONSTRAINT email_verifications_token_id_unique UNIQUE (token_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_email_verifications_user_id ON email_verifications(user_id, created_at);
+CREATE INDEX idx_email_verifications_expires_at ON email_verifications(expires_at);
+
+-- Comments
+COMMENT ON TABLE email_verifications IS 'Issued email verification tokens, used to make signed links single-use';
+COMMENT ON COLUMN email_verifications.token_id IS 'jti claim of the signed verification token';
+COMMENT ON COLUMN email_verifications.used_at IS 'Set when the link is confirmed, or when a newer link supersedes it';
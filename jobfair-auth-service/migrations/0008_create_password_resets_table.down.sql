@@ -0,0 +1,8 @@
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_password_resets_user_id;
+DROP INDEX IF EXISTS idx_password_resets_expires_at;
+
+-- Drop table
+DROP TABLE IF EXISTS password_resets;
@@ -0,0 +1,34 @@
+-- Create password_resets table for single-use emailed reset links
+CREATE TABLE IF NOT EXISTS password_resets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    token_id VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    ip_address VARCHAR(45),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_password_resets_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    -- Unique constraint
+    CONSTRAINT password_resets_token_id_unique UNIQUE (token_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_password_resets_user_id ON password_resets(user_id, created_at);
+CREATE INDEX idx_password_resets_expires_at ON password_resets(expires_at);
+
+-- Refresh tokens issued before this moment are rejected
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP;
+
+-- Comments
+COMMENT ON TABLE password_resets IS 'Issued password reset tokens, used to make signed links single-use';
+COMMENT ON COLUMN password_resets.token_id IS 'jti claim of the signed reset token';
+COMMENT ON COLUMN users.password_changed_at IS 'Last password change, refresh tokens issued earlier are invalid';
@@ -0,0 +1,18 @@
+-- Refresh tokens are now opaque, stored hashed and rotated on every use.
+-- A family groups every token descending from one login so reuse of a
+-- rotated token can revoke the whole chain.
+-- Stateless JWT refresh tokens were never stored, nothing to migrate
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id VARCHAR(64) NOT NULL;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS replaced_by_id INTEGER;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS revoked_reason VARCHAR(30);
+
+-- Index for family revocation
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- Comments
+COMMENT ON COLUMN refresh_tokens.token_hash IS 'SHA-256 of the opaque refresh token (hex)';
+COMMENT ON COLUMN refresh_tokens.family_id IS 'Shared by all tokens rotated from the same login';
+COMMENT ON COLUMN refresh_tokens.replaced_by_id IS 'Token issued when this one was rotated';
+COMMENT ON COLUMN refresh_tokens.revoked_reason IS 'rotated, logout, logout_all, reuse_detected or password_reset';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_admin_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_admin_audit_logs_admin_id;
+DROP INDEX IF EXISTS idx_admin_audit_logs_target;
+
+DROP TABLE IF EXISTS admin_audit_logs;
+
+COMMENT ON COLUMN refresh_tokens.revoked_reason IS 'rotated, logout, logout_all, reuse_detected, password_reset or session_revoked';
@@ -0,0 +1,29 @@
+-- Create admin_audit_logs table, every admin moderation action is recorded
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL,
+    action VARCHAR(50) NOT NULL,
+
+    -- Target
+    target_type VARCHAR(30) NOT NULL,
+    target_id INTEGER NOT NULL,
+
+    -- Context
+    reason TEXT,
+    details JSONB,
+    ip_address VARCHAR(45),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_admin_audit_logs_target ON admin_audit_logs(target_type, target_id);
+CREATE INDEX idx_admin_audit_logs_admin_id ON admin_audit_logs(admin_id);
+CREATE INDEX idx_admin_audit_logs_created_at ON admin_audit_logs(created_at);
+
+-- Comments
+COMMENT ON TABLE admin_audit_logs IS 'Append-only trail of admin moderation actions';
+COMMENT ON COLUMN admin_audit_logs.action IS 'user.deactivate or user.reactivate';
+COMMENT ON COLUMN admin_audit_logs.details IS 'JSON state before and after the action';
+COMMENT ON COLUMN refresh_tokens.revoked_reason IS 'rotated, logout, logout_all, reuse_detected, password_reset, session_revoked or deactivated';
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS update_resumes_updated_at ON resumes;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_resumes_default;
+DROP INDEX IF EXISTS idx_resumes_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS resumes;
@@ -0,0 +1,37 @@
+-- Create resumes table, the CV library of a job seeker
+CREATE TABLE IF NOT EXISTS resumes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_key VARCHAR(500) NOT NULL,
+    file_size BIGINT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    is_default BOOLEAN DEFAULT FALSE,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_resumes_user_id
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_resumes_user_id ON resumes(user_id) WHERE deleted_at IS NULL;
+
+-- At most one default resume per job seeker
+CREATE UNIQUE INDEX idx_resumes_default ON resumes(user_id) WHERE is_default AND deleted_at IS NULL;
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_resumes_updated_at BEFORE UPDATE ON resumes
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Comments
+COMMENT ON TABLE resumes IS 'Uploaded CVs of job seekers, applications reference them by id';
+COMMENT ON COLUMN resumes.file_key IS 'Storage key of the file, never served publicly';
+COMMENT ON COLUMN resumes.deleted_at IS 'Removed from the library, the file stays downloadable for applications that used it';
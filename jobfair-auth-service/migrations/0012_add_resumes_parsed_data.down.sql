@@ -0,0 +1,2 @@
+ALTER TABLE resumes DROP COLUMN IF EXISTS parsed_data;
+ALTER TABLE resumes DROP COLUMN IF EXISTS parse_status;
@@ -0,0 +1,8 @@
+-- Structured record extracted from the resume text (contact, skills, education, work history)
+ALTER TABLE resumes ADD COLUMN IF NOT EXISTS parse_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (parse_status IN ('pending', 'parsed', 'failed', 'unsupported'));
+ALTER TABLE resumes ADD COLUMN IF NOT EXISTS parsed_data JSONB;
+
+-- Comments
+COMMENT ON COLUMN resumes.parse_status IS 'pending for resumes uploaded before parsing existed, they are parsed when first used in an application';
+COMMENT ON COLUMN resumes.parsed_data IS 'Heuristic parse of the file text, see internal/resumeparser';
@@ -0,0 +1 @@
+COMMENT ON COLUMN refresh_tokens.revoked_reason IS 'rotated, logout, logout_all, reuse_detected or password_reset';
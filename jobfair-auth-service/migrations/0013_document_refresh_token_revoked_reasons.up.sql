@@ -0,0 +1,3 @@
+-- Document the revoke reasons added after 0009 (session revocation and account
+-- deactivation)
+COMMENT ON COLUMN refresh_tokens.revoked_reason IS 'rotated, logout, logout_all, reuse_detected, password_reset, session_revoked or deactivated';
@@ -10,6 +10,9 @@ import (
 	"jobfair-company-service/internal/repository"
 	"jobfair-company-service/internal/services"
 	"jobfair-company-service/pkg/database"
+	"jobfair-shared-libs/jwks"
+	"jobfair-shared-libs/notification"
+	"jobfair-shared-libs/storage"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,18 +39,76 @@ func main() {
 	companyRepo := repository.NewCompanyRepository(db)
 	jobRepo := repository.NewJobRepository(db)
 	applicationRepo := repository.NewApplicationRepository(db)
+	memberRepo := repository.NewCompanyMemberRepository(db)
+	invitationRepo := repository.NewCompanyInvitationRepository(db)
+	adminRepo := repository.NewAdminRepository(db)
+	verificationRepo := repository.NewCompanyVerificationRepository(db)
+	mediaRepo := repository.NewCompanyMediaRepository(db)
 
-	companyService := services.NewCompanyService(companyRepo, jobRepo, applicationRepo)
+	mailer, err := notification.NewMailer(notification.MailerConfig{
+		Provider:     cfg.MailProvider,
+		From:         cfg.MailFrom,
+		SMTPHost:     cfg.SMTPHost,
+		SMTPPort:     cfg.SMTPPort,
+		SMTPUsername: cfg.SMTPUsername,
+		SMTPPassword: cfg.SMTPPassword,
+	})
+	if err != nil {
+		log.Fatal("Failed to configure mailer:", err)
+	}
+
+	store, err := storage.New(storage.Config{
+		Provider:          cfg.StorageProvider,
+		LocalDir:          cfg.StorageLocalDir,
+		S3Endpoint:        cfg.S3Endpoint,
+		S3Region:          cfg.S3Region,
+		S3Bucket:          cfg.S3Bucket,
+		S3AccessKeyID:     cfg.S3AccessKeyID,
+		S3SecretAccessKey: cfg.S3SecretAccessKey,
+		S3ForcePathStyle:  cfg.S3ForcePathStyle,
+		S3PublicEndpoint:  cfg.S3PublicEndpoint,
+	})
+	if err != nil {
+		log.Fatal("Failed to configure file storage:", err)
+	}
+
+	companyService := services.NewCompanyService(companyRepo, memberRepo, jobRepo, applicationRepo, store)
 	jobService := services.NewJobService(jobRepo, companyRepo, applicationRepo)
-	applicationService := services.NewApplicationService(applicationRepo, jobRepo)
+	adminService := services.NewAdminService(adminRepo, companyRepo, jobRepo)
+	verificationService := services.NewVerificationService(verificationRepo, companyRepo, cfg.UploadPath)
+	mediaService := services.NewMediaService(mediaRepo, companyRepo, store)
+	// Resume library di auth service, lamaran hanya menyimpan resume_id
+	var resumeClient *services.ResumeClient
+	if cfg.InternalAPISecret != "" {
+		resumeClient = services.NewResumeClient(cfg.AuthServiceURL, cfg.InternalAPISecret)
+	} else {
+		log.Println("⚠️  INTERNAL_API_SECRET not set, only resume_url can be used when applying")
+	}
+	applicationService := services.NewApplicationService(applicationRepo, jobRepo, resumeClient)
+	membershipService := services.NewMembershipService(memberRepo, invitationRepo, companyRepo, mailer, services.InvitationSettings{
+		URL: cfg.InvitationURL,
+		TTL: cfg.InvitationTTL,
+	})
 
-	companyHandler := handlers.NewCompanyHandler(companyService)
-	jobHandler := handlers.NewJobHandler(companyService, jobService)
-	applicationHandler := handlers.NewApplicationHandler(companyService, applicationService)
+	companyHandler := handlers.NewCompanyHandler(companyService, membershipService)
+	jobHandler := handlers.NewJobHandler(membershipService, jobService)
+	applicationHandler := handlers.NewApplicationHandler(membershipService, applicationService)
+	memberHandler := handlers.NewMemberHandler(membershipService)
+	jobBoardHandler := handlers.NewJobBoardHandler(jobService)
+	mediaHandler := handlers.NewMediaHandler(membershipService, mediaService)
+	fileHandler := handlers.NewFileHandler(store, "companies/", cfg.FileURLTTL)
+	adminHandler := handlers.NewAdminHandler(adminService, companyService)
+	verificationHandler := handlers.NewVerificationHandler(membershipService, verificationService)
 
 	router := gin.Default()
 	router.MaxMultipartMemory = 10 << 20
 
+	// Hanya percaya X-Forwarded-For dari proxy yang dikenal (API gateway),
+	// kalau tidak client bisa memalsukan IP yang dicatat di audit log admin
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
+
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
@@ -68,12 +129,21 @@ func main() {
 		})
 	})
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "default-secret"
+	// Access token diverifikasi dengan public key auth service, service ini tidak bisa membuat token
+	keys := jwks.NewKeySet(cfg.JWKSURL, cfg.JWKSRefreshInterval)
+
+	// Tolak token dari sesi yang sudah di-revoke (butuh INTERNAL_API_SECRET yang sama dengan auth service)
+	var sessionChecker *middleware.SessionChecker
+	if cfg.InternalAPISecret != "" {
+		sessionChecker = middleware.NewSessionChecker(cfg.AuthServiceURL, cfg.InternalAPISecret, cfg.SessionCacheTTL)
+	} else {
+		log.Println("⚠️  INTERNAL_API_SECRET not set, revoked sessions are not checked")
 	}
 
-	jwtMiddleware := middleware.JWTMiddleware(jwtSecret)
+	jwtMiddleware := middleware.JWTMiddleware(keys, sessionChecker)
+	if cfg.GatewaySecret != "" {
+		jwtMiddleware = middleware.GatewayAuthMiddleware(cfg.GatewaySecret, keys, sessionChecker)
+	}
 
 	api := router.Group("/api/v1")
 	{
@@ -81,37 +151,71 @@ func main() {
 		{
 			public.GET("/companies", companyHandler.ListCompanies)
 			public.GET("/companies/:id", companyHandler.GetCompany)
+			public.GET("/companies/:id/media", mediaHandler.ListMedia)
 			// public.GET("/jobs/:id", jobHandler.GetJob)
+
+			// Job board untuk pencari kerja, lintas semua perusahaan
+			public.GET("/public/jobs", jobBoardHandler.SearchJobs)
+			public.GET("/public/jobs/:slug", jobBoardHandler.GetJob)
+
+			// Media perusahaan (logo, banner, galeri, video)
+			public.GET("/files/*key", fileHandler.ServeFile)
 		}
 
+		// Setiap route terproteksi wajib punya RequirePermission, lihat matriks di middleware/role_middleware.go
 		protected := api.Group("")
 		protected.Use(jwtMiddleware)
 		{
+			jobManage := middleware.RequirePermission(middleware.PermissionJobManage)
+
 			jobs := protected.Group("/jobs")
 			{
-				jobs.GET("", jobHandler.ListJobs)
-				jobs.POST("", jobHandler.CreateJob)
-				jobs.GET("/:id", jobHandler.GetJob)
-				jobs.PUT("/:id", jobHandler.UpdateJob)
-				jobs.DELETE("/:id", jobHandler.DeleteJob)
-				jobs.POST("/:id/publish", jobHandler.PublishJob)
-				jobs.POST("/:id/close", jobHandler.CloseJob)
+				jobs.GET("", jobManage, jobHandler.ListJobs)
+				jobs.POST("", jobManage, jobHandler.CreateJob)
+				jobs.GET("/:id", middleware.RequirePermission(middleware.PermissionJobRead), jobHandler.GetJob)
+				jobs.PUT("/:id", jobManage, jobHandler.UpdateJob)
+				jobs.DELETE("/:id", jobManage, jobHandler.DeleteJob)
+				jobs.POST("/:id/publish", jobManage, jobHandler.PublishJob)
+				jobs.POST("/:id/close", jobManage, jobHandler.CloseJob)
+				jobs.POST("/:id/apply", middleware.RequirePermission(middleware.PermissionJobApply), applicationHandler.ApplyToJob)
 
 				// Aplikasi untuk job tertentu
-				jobs.GET("/:id/applications", applicationHandler.GetApplicationsByJobID)
+				jobs.GET("/:id/applications", middleware.RequirePermission(middleware.PermissionApplicationReview), applicationHandler.GetApplicationsByJobID)
 			}
 
-			protected.GET("/my-company", companyHandler.GetMyCompany)
-			protected.POST("/companies", companyHandler.CreateCompany)
-			protected.PUT("/companies/:id", companyHandler.UpdateCompany)
+			companyOwn := middleware.RequirePermission(middleware.PermissionCompanyOwn)
+			companyManage := middleware.RequirePermission(middleware.PermissionCompanyManage)
+
+			protected.GET("/my-company", companyOwn, companyHandler.GetMyCompany)
+			protected.GET("/my-company/verification", companyOwn, verificationHandler.GetMyRequest)
+			protected.POST("/my-company/verification", companyOwn, verificationHandler.SubmitRequest)
+			protected.POST("/companies", middleware.RequirePermission(middleware.PermissionCompanyCreate), companyHandler.CreateCompany)
+			protected.PUT("/companies/:id", companyManage, companyHandler.UpdateCompany)
+
+			protected.POST("/companies/:id/logo", companyManage, companyHandler.UploadLogo)
+			protected.POST("/companies/:id/banner", companyManage, companyHandler.UploadBanner)
 
-			protected.POST("/companies/:id/logo", companyHandler.UploadLogo)
-			protected.POST("/companies/:id/banner", companyHandler.UploadBanner)
-			protected.POST("/companies/:id/videos", companyHandler.UploadVideo)
-			protected.POST("/companies/:id/gallery", companyHandler.UploadGallery)
+			// Galeri dan video virtual booth, jumlah maksimal mengikuti subscription tier
+			protected.POST("/companies/:id/videos", companyManage, mediaHandler.UploadVideo)
+			protected.POST("/companies/:id/gallery", companyManage, mediaHandler.UploadGallery)
+			protected.POST("/companies/:id/media/reorder", companyManage, mediaHandler.ReorderMedia)
+			protected.PUT("/companies/:id/media/:media_id", companyManage, mediaHandler.UpdateMedia)
+			protected.DELETE("/companies/:id/media/:media_id", companyManage, mediaHandler.DeleteMedia)
 
-			protected.GET("/companies/:id/analytics", companyHandler.GetAnalytics)
-			protected.GET("/dashboard", companyHandler.GetDashboard)
+			protected.GET("/companies/:id/analytics", middleware.RequirePermission(middleware.PermissionCompanyAnalytics), companyHandler.GetAnalytics)
+			protected.GET("/dashboard", companyOwn, companyHandler.GetDashboard)
+
+			// Anggota tim perusahaan, izin per role anggota dicek di handler
+			members := protected.Group("/my-company", middleware.RequirePermission(middleware.PermissionCompanyMembers))
+			{
+				members.GET("/members", memberHandler.ListMembers)
+				members.PUT("/members/:id", memberHandler.UpdateMemberRole)
+				members.DELETE("/members/:id", memberHandler.RemoveMember)
+				members.GET("/invitations", memberHandler.ListInvitations)
+				members.POST("/invitations", memberHandler.InviteMember)
+				members.DELETE("/invitations/:id", memberHandler.RevokeInvitation)
+			}
+			protected.POST("/invitations/accept", middleware.RequirePermission(middleware.PermissionCompanyMembers), memberHandler.AcceptInvitation)
 
 			// protected.POST("/jobs", jobHandler.CreateJob)
 			// protected.GET("/jobs", jobHandler.ListJobs)
@@ -120,11 +224,41 @@ func main() {
 			// protected.POST("/jobs/:id/publish", jobHandler.PublishJob)
 			// protected.POST("/jobs/:id/close", jobHandler.CloseJob)
 
-			protected.GET("/applications", applicationHandler.ListApplications)
-			protected.GET("/applications/:id", applicationHandler.GetApplication)
+			applicationReview := middleware.RequirePermission(middleware.PermissionApplicationReview)
+
+			protected.GET("/applications", applicationReview, applicationHandler.ListApplications)
+			protected.GET("/applications/:id", middleware.RequirePermission(middleware.PermissionApplicationRead), applicationHandler.GetApplication)
 			// protected.GET("/jobs/:job_id/applications", applicationHandler.GetApplicationsByJobID)
-			protected.PUT("/applications/:id/status", applicationHandler.UpdateApplicationStatus)
-			protected.GET("/applications/stats", applicationHandler.GetApplicationStats)
+			protected.PUT("/applications/:id/status", applicationReview, applicationHandler.UpdateApplicationStatus)
+			protected.GET("/applications/:id/history", applicationReview, applicationHandler.GetApplicationHistory)
+			protected.GET("/applications/:id/resume", applicationReview, applicationHandler.GetApplicationResume)
+			protected.GET("/applications/stats", applicationReview, applicationHandler.GetApplicationStats)
+
+			// Lamaran milik job seeker
+			applicationOwn := middleware.RequirePermission(middleware.PermissionApplicationOwn)
+
+			protected.GET("/my-applications", applicationOwn, applicationHandler.ListMyApplications)
+			protected.GET("/my-applications/:id", applicationOwn, applicationHandler.GetMyApplication)
+			protected.POST("/my-applications/:id/withdraw", applicationOwn, applicationHandler.WithdrawApplication)
+			protected.GET("/my-applications/:id/history", applicationOwn, applicationHandler.GetMyApplicationHistory)
+		}
+
+		// Moderasi platform, hanya untuk admin. Setiap aksi dicatat di admin_audit_logs
+		admin := api.Group("/admin", jwtMiddleware, middleware.RequirePermission(middleware.PermissionPlatformModerate))
+		{
+			admin.GET("/companies", adminHandler.ListCompanies)
+			admin.POST("/companies/:id/verify", adminHandler.VerifyCompany)
+			admin.POST("/companies/:id/unverify", adminHandler.UnverifyCompany)
+			admin.POST("/companies/:id/feature", adminHandler.FeatureCompany)
+			admin.POST("/companies/:id/unfeature", adminHandler.UnfeatureCompany)
+			admin.POST("/jobs/:id/close", adminHandler.ForceCloseJob)
+			admin.GET("/audit-logs/companies", adminHandler.ListAuditLogs)
+
+			admin.GET("/verification-requests", verificationHandler.ListRequests)
+			admin.GET("/verification-requests/:id", verificationHandler.GetRequest)
+			admin.GET("/verification-requests/:id/documents/:document_id", verificationHandler.DownloadDocument)
+			admin.POST("/verification-requests/:id/approve", verificationHandler.ApproveRequest)
+			admin.POST("/verification-requests/:id/reject", verificationHandler.RejectRequest)
 		}
 
 	}
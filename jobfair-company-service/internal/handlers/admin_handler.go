@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+	"jobfair-company-service/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminService   *services.AdminService
+	companyService *services.CompanyService
+}
+
+func NewAdminHandler(adminService *services.AdminService, companyService *services.CompanyService) *AdminHandler {
+	return &AdminHandler{
+		adminService:   adminService,
+		companyService: companyService,
+	}
+}
+
+// ListCompanies supports industry, city, is_verified, is_featured, page and limit
+func (h *AdminHandler) ListCompanies(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	params := utils.NewPaginationParams(page, limit)
+
+	filters := make(map[string]interface{})
+	if industry := c.Query("industry"); industry != "" {
+		filters["industry"] = industry
+	}
+	if city := c.Query("city"); city != "" {
+		filters["city"] = city
+	}
+	if isVerified, err := strconv.ParseBool(c.Query("is_verified")); err == nil {
+		filters["is_verified"] = isVerified
+	}
+	if isFeatured, err := strconv.ParseBool(c.Query("is_featured")); err == nil {
+		filters["is_featured"] = isFeatured
+	}
+
+	companies, total, err := h.companyService.ListCompanies(params.Limit, params.GetOffset(), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve companies", "SERVER_ERROR", nil))
+		return
+	}
+
+	pagination := models.PaginationMeta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: utils.CalculateTotalPages(total, params.Limit),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("Companies retrieved successfully", companies, pagination))
+}
+
+func (h *AdminHandler) VerifyCompany(c *gin.Context) {
+	id, ok := idParam(c, "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.VerifyCompanyRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	company, err := h.adminService.VerifyCompany(c.GetUint("user_id"), id, &req, c.ClientIP())
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Company verified successfully", company))
+}
+
+func (h *AdminHandler) UnverifyCompany(c *gin.Context) {
+	id, ok := idParam(c, "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.ModerationRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	company, err := h.adminService.UnverifyCompany(c.GetUint("user_id"), id, req.Reason, c.ClientIP())
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Company verification removed", company))
+}
+
+func (h *AdminHandler) FeatureCompany(c *gin.Context) {
+	h.setFeatured(c, true, "Company featured successfully")
+}
+
+func (h *AdminHandler) UnfeatureCompany(c *gin.Context) {
+	h.setFeatured(c, false, "Company unfeatured successfully")
+}
+
+func (h *AdminHandler) setFeatured(c *gin.Context, featured bool, message string) {
+	id, ok := idParam(c, "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	var req models.ModerationRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	company, err := h.adminService.SetCompanyFeatured(c.GetUint("user_id"), id, featured, req.Reason, c.ClientIP())
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(message, company))
+}
+
+func (h *AdminHandler) ForceCloseJob(c *gin.Context) {
+	id, ok := idParam(c, "Invalid job ID")
+	if !ok {
+		return
+	}
+
+	var req models.ForceCloseJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	job, err := h.adminService.ForceCloseJob(c.GetUint("user_id"), id, req.Reason, c.ClientIP())
+	if err != nil {
+		adminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Job closed successfully", job))
+}
+
+// ListAuditLogs supports admin_id, target_type, target_id, action, page and limit
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	params := utils.NewPaginationParams(page, limit)
+
+	filter := models.AuditLogFilter{
+		TargetType: c.Query("target_type"),
+		Action:     c.Query("action"),
+	}
+	if adminID, err := strconv.ParseUint(c.Query("admin_id"), 10, 32); err == nil {
+		filter.AdminID = uint(adminID)
+	}
+	if targetID, err := strconv.ParseUint(c.Query("target_id"), 10, 32); err == nil {
+		filter.TargetID = uint(targetID)
+	}
+
+	logs, total, err := h.adminService.ListAuditLogs(filter, params.Limit, params.GetOffset())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve audit logs", "SERVER_ERROR", nil))
+		return
+	}
+
+	pagination := models.PaginationMeta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: utils.CalculateTotalPages(total, params.Limit),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("Audit logs retrieved successfully", logs, pagination))
+}
+
+func idParam(c *gin.Context, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(message, "INVALID_ID", nil))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// bindOptionalJSON binds the body when one is sent, the reason of most
+// moderation actions is optional
+func bindOptionalJSON(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return false
+	}
+	return true
+}
+
+func adminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCompanyNotFound), errors.Is(err, services.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrCompanyAlreadyVerified),
+		errors.Is(err, services.ErrCompanyNotVerified),
+		errors.Is(err, services.ErrCompanyAlreadyFeatured),
+		errors.Is(err, services.ErrCompanyNotFeatured),
+		errors.Is(err, services.ErrJobAlreadyForceClosed):
+		c.JSON(http.StatusConflict, models.ErrorResponse(err.Error(), "CONFLICT", nil))
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Moderation action failed", "SERVER_ERROR", nil))
+	}
+}
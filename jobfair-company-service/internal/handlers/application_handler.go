@@ -1,27 +1,74 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"jobfair-company-service/internal/models"
 	"jobfair-company-service/internal/services"
+	"jobfair-company-service/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ApplicationHandler struct {
-	companyService     *services.CompanyService
+	memberships        *services.MembershipService
 	applicationService *services.ApplicationService
 }
 
-func NewApplicationHandler(companyService *services.CompanyService, applicationService *services.ApplicationService) *ApplicationHandler {
+func NewApplicationHandler(memberships *services.MembershipService, applicationService *services.ApplicationService) *ApplicationHandler {
 	return &ApplicationHandler{
-		companyService:     companyService,
+		memberships:        memberships,
 		applicationService: applicationService,
 	}
 }
 
+func (h *ApplicationHandler) ApplyToJob(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	if c.GetString("user_type") != "job_seeker" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only job seekers can apply to jobs", "FORBIDDEN", nil))
+		return
+	}
+
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid job ID", "INVALID_ID", nil))
+		return
+	}
+
+	var req models.ApplyJobRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	// Resume file is optional, resume_id or resume_url can be sent instead and
+	// without any of them the default resume of the library is used
+	resume, _ := c.FormFile("resume")
+
+	application, err := h.applicationService.ApplyToJob(uint(jobID), userID.(uint), &req, resume)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAlreadyApplied):
+			c.JSON(http.StatusConflict, models.ErrorResponse(err.Error(), "ALREADY_APPLIED", nil))
+		case errors.Is(err, services.ErrResumeLibraryOffline):
+			c.JSON(http.StatusServiceUnavailable, models.ErrorResponse(err.Error(), "SERVICE_UNAVAILABLE", nil))
+		default:
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "APPLY_FAILED", nil))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("Application submitted successfully", application))
+}
+
 func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -35,25 +82,63 @@ func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse("Application retrieved successfully", application))
+	if !companyAccess(c, h.memberships, application.CompanyID, models.MemberPermissionViewCompany) {
+		return
+	}
+
+	detail, err := h.applicationService.GetApplicationDetail(application)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve application", "SERVER_ERROR", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Application retrieved successfully", detail))
 }
 
-func (h *ApplicationHandler) ListApplications(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+// GetApplicationResume returns a time-limited download link to the resume of
+// an application to one of the company's jobs
+func (h *ApplicationHandler) GetApplicationResume(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid application ID", "INVALID_ID", nil))
 		return
 	}
 
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
+	application, err := h.applicationService.GetApplication(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+		c.JSON(http.StatusNotFound, models.ErrorResponse("Application not found", "NOT_FOUND", nil))
+		return
+	}
+
+	if !companyAccess(c, h.memberships, application.CompanyID, models.MemberPermissionViewCompany) {
+		return
+	}
+
+	link, err := h.applicationService.ResumeDownloadLink(application)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoStoredResume), errors.Is(err, services.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "RESUME_NOT_FOUND", nil))
+		case errors.Is(err, services.ErrResumeLibraryOffline):
+			c.JSON(http.StatusServiceUnavailable, models.ErrorResponse(err.Error(), "SERVICE_UNAVAILABLE", nil))
+		default:
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to create resume download link", "SERVER_ERROR", nil))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Resume download link created", link))
+}
+
+func (h *ApplicationHandler) ListApplications(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
 		return
 	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset := (page - 1) * limit
+	params := utils.NewPaginationParams(page, limit)
 
 	filters := make(map[string]interface{})
 	if status := c.Query("status"); status != "" {
@@ -62,33 +147,38 @@ func (h *ApplicationHandler) ListApplications(c *gin.Context) {
 	if jobID := c.Query("job_id"); jobID != "" {
 		filters["job_id"] = jobID
 	}
+	// skills=go,docker matches applicants whose parsed resume lists all of them
+	if skills := c.Query("skills"); skills != "" {
+		var wanted []string
+		for _, skill := range strings.Split(skills, ",") {
+			if skill = strings.ToLower(strings.TrimSpace(skill)); skill != "" {
+				wanted = append(wanted, skill)
+			}
+		}
+		if len(wanted) > 0 {
+			filters["resume_skills"] = wanted
+		}
+	}
 
-	applications, total, err := h.applicationService.ListApplications(company.ID, limit, offset, filters)
+	applications, total, err := h.applicationService.ListApplications(member.CompanyID, params.Limit, params.GetOffset(), filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve applications", "SERVER_ERROR", nil))
 		return
 	}
 
 	pagination := models.PaginationMeta{
-		Page:       page,
-		Limit:      limit,
+		Page:       params.Page,
+		Limit:      params.Limit,
 		TotalItems: total,
-		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		TotalPages: utils.CalculateTotalPages(total, params.Limit),
 	}
 
 	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("Applications retrieved successfully", applications, pagination))
 }
 
 func (h *ApplicationHandler) GetApplicationsByJobID(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
 		return
 	}
 
@@ -98,7 +188,7 @@ func (h *ApplicationHandler) GetApplicationsByJobID(c *gin.Context) {
 		return
 	}
 
-	applications, err := h.applicationService.GetApplicationsByJobID(uint(jobID), company.ID)
+	applications, err := h.applicationService.GetApplicationsByJobID(uint(jobID), member.CompanyID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "FETCH_FAILED", nil))
 		return
@@ -108,15 +198,113 @@ func (h *ApplicationHandler) GetApplicationsByJobID(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionReviewApplications)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid application ID", "INVALID_ID", nil))
+		return
+	}
+
+	var req models.UpdateApplicationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	if err := h.applicationService.UpdateApplicationStatus(uint(id), member.CompanyID, member.UserID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "UPDATE_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Application status updated successfully", nil))
+}
+
+func (h *ApplicationHandler) GetApplicationHistory(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid application ID", "INVALID_ID", nil))
+		return
+	}
+
+	history, err := h.applicationService.GetStatusHistory(uint(id), member.CompanyID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "FETCH_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Application history retrieved successfully", history))
+}
+
+func (h *ApplicationHandler) GetApplicationStats(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
+		return
+	}
+
+	stats, err := h.applicationService.GetApplicationStats(member.CompanyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve stats", "SERVER_ERROR", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Application stats retrieved successfully", stats))
+}
+
+func (h *ApplicationHandler) ListMyApplications(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
 		return
 	}
 
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
+	if c.GetString("user_type") != "job_seeker" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only job seekers can view their applications", "FORBIDDEN", nil))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	params := utils.NewPaginationParams(page, limit)
+
+	filters := make(map[string]interface{})
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	applications, total, err := h.applicationService.ListMyApplications(userID.(uint), params.Limit, params.GetOffset(), filters)
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve applications", "SERVER_ERROR", nil))
+		return
+	}
+
+	pagination := models.PaginationMeta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: utils.CalculateTotalPages(total, params.Limit),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("Applications retrieved successfully", applications, pagination))
+}
+
+func (h *ApplicationHandler) GetMyApplication(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	if c.GetString("user_type") != "job_seeker" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only job seekers can view their applications", "FORBIDDEN", nil))
 		return
 	}
 
@@ -126,40 +314,70 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		Status models.ApplicationStatus `json:"status" binding:"required"`
+	application, err := h.applicationService.GetMyApplication(uint(id), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse("Application not found", "NOT_FOUND", nil))
+		return
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Application retrieved successfully", application))
+}
+
+func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
 		return
 	}
 
-	if err := h.applicationService.UpdateApplicationStatus(uint(id), company.ID, req.Status); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "UPDATE_FAILED", nil))
+	if c.GetString("user_type") != "job_seeker" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only job seekers can withdraw applications", "FORBIDDEN", nil))
 		return
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse("Application status updated successfully", nil))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid application ID", "INVALID_ID", nil))
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	// Body is optional; a withdrawal without a note is valid
+	_ = c.ShouldBindJSON(&req)
+
+	if err := h.applicationService.WithdrawApplication(uint(id), userID.(uint), req.Note); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "WITHDRAW_FAILED", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Application withdrawn successfully", nil))
 }
 
-func (h *ApplicationHandler) GetApplicationStats(c *gin.Context) {
+func (h *ApplicationHandler) GetMyApplicationHistory(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
 		return
 	}
 
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
+	if c.GetString("user_type") != "job_seeker" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Only job seekers can view their applications", "FORBIDDEN", nil))
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid application ID", "INVALID_ID", nil))
 		return
 	}
 
-	stats, err := h.applicationService.GetApplicationStats(company.ID)
+	history, err := h.applicationService.GetMyStatusHistory(uint(id), userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve stats", "SERVER_ERROR", nil))
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "FETCH_FAILED", nil))
 		return
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse("Application stats retrieved successfully", stats))
+	c.JSON(http.StatusOK, models.SuccessResponse("Application history retrieved successfully", history))
 }
@@ -11,11 +11,12 @@ import (
 )
 
 type CompanyHandler struct {
-	service *services.CompanyService
+	service     *services.CompanyService
+	memberships *services.MembershipService
 }
 
-func NewCompanyHandler(service *services.CompanyService) *CompanyHandler {
-	return &CompanyHandler{service: service}
+func NewCompanyHandler(service *services.CompanyService, memberships *services.MembershipService) *CompanyHandler {
+	return &CompanyHandler{service: service, memberships: memberships}
 }
 
 func (h *CompanyHandler) CreateCompany(c *gin.Context) {
@@ -57,13 +58,12 @@ func (h *CompanyHandler) GetCompany(c *gin.Context) {
 }
 
 func (h *CompanyHandler) GetMyCompany(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
 		return
 	}
 
-	company, err := h.service.GetCompanyByUserID(userID.(uint))
+	company, err := h.service.GetCompanyByID(member.CompanyID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
 		return
@@ -79,6 +79,10 @@ func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
 		return
 	}
 
+	if !companyAccess(c, h.memberships, uint(id), models.MemberPermissionManageCompany) {
+		return
+	}
+
 	var req models.UpdateCompanyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
@@ -101,25 +105,27 @@ func (h *CompanyHandler) UploadFile(c *gin.Context, fileType string) {
 		return
 	}
 
+	if !companyAccess(c, h.memberships, uint(id), models.MemberPermissionManageCompany) {
+		return
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse("File upload failed", "UPLOAD_FAILED", err.Error()))
 		return
 	}
 
-	url, err := h.service.UploadFile(uint(id), file, fileType)
+	uploaded, err := h.service.UploadFile(uint(id), file, fileType)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "UPLOAD_FAILED", nil))
 		return
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse("File uploaded successfully", gin.H{"url": url}))
+	c.JSON(http.StatusOK, models.SuccessResponse("File uploaded successfully", uploaded))
 }
 
-func (h *CompanyHandler) UploadLogo(c *gin.Context)    { h.UploadFile(c, "logo") }
-func (h *CompanyHandler) UploadBanner(c *gin.Context)  { h.UploadFile(c, "banner") }
-func (h *CompanyHandler) UploadVideo(c *gin.Context)   { h.UploadFile(c, "video") }
-func (h *CompanyHandler) UploadGallery(c *gin.Context) { h.UploadFile(c, "gallery") }
+func (h *CompanyHandler) UploadLogo(c *gin.Context)   { h.UploadFile(c, "logo") }
+func (h *CompanyHandler) UploadBanner(c *gin.Context) { h.UploadFile(c, "banner") }
 
 func (h *CompanyHandler) GetAnalytics(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -128,6 +134,10 @@ func (h *CompanyHandler) GetAnalytics(c *gin.Context) {
 		return
 	}
 
+	if !companyAccess(c, h.memberships, uint(id), models.MemberPermissionViewCompany) {
+		return
+	}
+
 	analytics, err := h.service.GetAnalytics(uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse("Analytics not found", "NOT_FOUND", nil))
@@ -138,19 +148,12 @@ func (h *CompanyHandler) GetAnalytics(c *gin.Context) {
 }
 
 func (h *CompanyHandler) GetDashboard(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.service.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
 		return
 	}
 
-	stats, err := h.service.GetDashboardStats(company.ID)
+	stats, err := h.service.GetDashboardStats(member.CompanyID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve dashboard stats", "SERVER_ERROR", nil))
 		return
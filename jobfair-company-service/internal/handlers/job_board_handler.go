@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+	"jobfair-company-service/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobBoardHandler serves the public job board, jobs of every company that
+// are active, published and not expired
+type JobBoardHandler struct {
+	jobService *services.JobService
+}
+
+func NewJobBoardHandler(jobService *services.JobService) *JobBoardHandler {
+	return &JobBoardHandler{jobService: jobService}
+}
+
+// SearchJobs supports q, job_type and job_level (comma separated), city,
+// country, industry, is_remote, salary_min, salary_max, sort (relevance,
+// recent or salary), page and limit. Facet counts are included unless
+// facets=false.
+func (h *JobBoardHandler) SearchJobs(c *gin.Context) {
+	filter, ok := jobSearchFilter(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	params := utils.NewPaginationParams(page, limit)
+
+	jobs, total, err := h.jobService.SearchPublicJobs(filter, params.Limit, params.GetOffset())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to search jobs", "SERVER_ERROR", nil))
+		return
+	}
+
+	pagination := models.PaginationMeta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: utils.CalculateTotalPages(total, params.Limit),
+	}
+
+	response := models.JobSearchResponse{
+		PaginatedResponse: models.PaginatedSuccessResponse("Jobs retrieved successfully", jobs, pagination),
+	}
+
+	if withFacets, err := strconv.ParseBool(c.DefaultQuery("facets", "true")); err != nil || withFacets {
+		response.Facets, err = h.jobService.JobFacets(filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to count job facets", "SERVER_ERROR", nil))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *JobBoardHandler) GetJob(c *gin.Context) {
+	job, err := h.jobService.GetPublicJob(c.Param("slug"))
+	if err != nil {
+		if errors.Is(err, services.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse("Job not found", "NOT_FOUND", nil))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve job", "SERVER_ERROR", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Job retrieved successfully", job))
+}
+
+func jobSearchFilter(c *gin.Context) (models.JobSearchFilter, bool) {
+	filter := models.JobSearchFilter{
+		Query:    strings.TrimSpace(c.Query("q")),
+		City:     strings.TrimSpace(c.Query("city")),
+		Country:  strings.TrimSpace(c.Query("country")),
+		Industry: strings.TrimSpace(c.Query("industry")),
+		Sort:     models.JobSort(c.Query("sort")),
+	}
+
+	for _, jobType := range splitQuery(c.Query("job_type")) {
+		filter.JobTypes = append(filter.JobTypes, models.JobType(jobType))
+	}
+	for _, jobLevel := range splitQuery(c.Query("job_level")) {
+		filter.JobLevels = append(filter.JobLevels, models.JobLevel(jobLevel))
+	}
+
+	if value := c.Query("is_remote"); value != "" {
+		isRemote, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse("is_remote must be true or false", "VALIDATION_ERROR", nil))
+			return filter, false
+		}
+		filter.IsRemote = &isRemote
+	}
+
+	var err error
+	if filter.SalaryMin, err = salaryQuery(c, "salary_min"); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+		return filter, false
+	}
+	if filter.SalaryMax, err = salaryQuery(c, "salary_max"); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+		return filter, false
+	}
+
+	switch filter.Sort {
+	case models.JobSortRelevance, models.JobSortRecent, models.JobSortSalary:
+	case "":
+		filter.Sort = models.JobSortRecent
+		if filter.Query != "" {
+			filter.Sort = models.JobSortRelevance
+		}
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("sort must be relevance, recent or salary", "VALIDATION_ERROR", nil))
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func salaryQuery(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	salary, err := strconv.Atoi(value)
+	if err != nil || salary < 0 {
+		return 0, errors.New(name + " must be a positive number")
+	}
+	return salary, nil
+}
+
+// splitQuery splits a comma separated query value, ignoring empty items
+func splitQuery(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
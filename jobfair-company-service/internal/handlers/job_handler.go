@@ -11,27 +11,20 @@ import (
 )
 
 type JobHandler struct {
-	companyService *services.CompanyService
-	jobService     *services.JobService
+	memberships *services.MembershipService
+	jobService  *services.JobService
 }
 
-func NewJobHandler(companyService *services.CompanyService, jobService *services.JobService) *JobHandler {
+func NewJobHandler(memberships *services.MembershipService, jobService *services.JobService) *JobHandler {
 	return &JobHandler{
-		companyService: companyService,
-		jobService:     jobService,
+		memberships: memberships,
+		jobService:  jobService,
 	}
 }
 
 func (h *JobHandler) CreateJob(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageJobs)
+	if !ok {
 		return
 	}
 
@@ -41,7 +34,7 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		return
 	}
 
-	job, err := h.jobService.CreateJob(company.ID, &req)
+	job, err := h.jobService.CreateJob(member.CompanyID, &req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "CREATE_FAILED", nil))
 		return
@@ -67,15 +60,8 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 }
 
 func (h *JobHandler) UpdateJob(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageJobs)
+	if !ok {
 		return
 	}
 
@@ -91,7 +77,7 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 		return
 	}
 
-	job, err := h.jobService.UpdateJob(uint(id), company.ID, &req)
+	job, err := h.jobService.UpdateJob(uint(id), member.CompanyID, &req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "UPDATE_FAILED", nil))
 		return
@@ -101,15 +87,8 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 }
 
 func (h *JobHandler) DeleteJob(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageJobs)
+	if !ok {
 		return
 	}
 
@@ -119,7 +98,7 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 		return
 	}
 
-	if err := h.jobService.DeleteJob(uint(id), company.ID); err != nil {
+	if err := h.jobService.DeleteJob(uint(id), member.CompanyID); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "DELETE_FAILED", nil))
 		return
 	}
@@ -128,15 +107,8 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 }
 
 func (h *JobHandler) ListJobs(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
 		return
 	}
 
@@ -152,7 +124,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 		filters["job_type"] = jobType
 	}
 
-	jobs, total, err := h.jobService.ListJobs(company.ID, limit, offset, filters)
+	jobs, total, err := h.jobService.ListJobs(member.CompanyID, limit, offset, filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve jobs", "SERVER_ERROR", nil))
 		return
@@ -169,15 +141,8 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 }
 
 func (h *JobHandler) PublishJob(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageJobs)
+	if !ok {
 		return
 	}
 
@@ -187,7 +152,7 @@ func (h *JobHandler) PublishJob(c *gin.Context) {
 		return
 	}
 
-	if err := h.jobService.PublishJob(uint(id), company.ID); err != nil {
+	if err := h.jobService.PublishJob(uint(id), member.CompanyID); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "PUBLISH_FAILED", nil))
 		return
 	}
@@ -196,15 +161,8 @@ func (h *JobHandler) PublishJob(c *gin.Context) {
 }
 
 func (h *JobHandler) CloseJob(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
-		return
-	}
-
-	company, err := h.companyService.GetCompanyByUserID(userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageJobs)
+	if !ok {
 		return
 	}
 
@@ -214,7 +172,7 @@ func (h *JobHandler) CloseJob(c *gin.Context) {
 		return
 	}
 
-	if err := h.jobService.CloseJob(uint(id), company.ID); err != nil {
+	if err := h.jobService.CloseJob(uint(id), member.CompanyID); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "CLOSE_FAILED", nil))
 		return
 	}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	memberships *services.MembershipService
+	media       *services.MediaService
+}
+
+func NewMediaHandler(memberships *services.MembershipService, media *services.MediaService) *MediaHandler {
+	return &MediaHandler{memberships: memberships, media: media}
+}
+
+// ListMedia is public, the gallery and videos are part of the virtual booth
+func (h *MediaHandler) ListMedia(c *gin.Context) {
+	id, ok := idParam(c, "Invalid company ID")
+	if !ok {
+		return
+	}
+
+	list, err := h.media.ListMedia(id)
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve media", "SERVER_ERROR", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media retrieved successfully", list))
+}
+
+func (h *MediaHandler) UploadGallery(c *gin.Context) { h.upload(c, models.MediaTypeGallery) }
+func (h *MediaHandler) UploadVideo(c *gin.Context)   { h.upload(c, models.MediaTypeVideo) }
+
+func (h *MediaHandler) upload(c *gin.Context, mediaType models.MediaType) {
+	id, ok := h.manageAccess(c)
+	if !ok {
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("File upload failed", "UPLOAD_FAILED", err.Error()))
+		return
+	}
+
+	var req models.UploadMediaRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	media, err := h.media.UploadMedia(id, mediaType, file, &req)
+	if err != nil {
+		mediaError(c, err, "UPLOAD_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("File uploaded successfully", media))
+}
+
+func (h *MediaHandler) UpdateMedia(c *gin.Context) {
+	id, ok := h.manageAccess(c)
+	if !ok {
+		return
+	}
+
+	mediaID, ok := mediaIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	media, err := h.media.UpdateMedia(id, mediaID, &req)
+	if err != nil {
+		mediaError(c, err, "UPDATE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media updated successfully", media))
+}
+
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	id, ok := h.manageAccess(c)
+	if !ok {
+		return
+	}
+
+	mediaID, ok := mediaIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.media.DeleteMedia(id, mediaID); err != nil {
+		mediaError(c, err, "DELETE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media deleted successfully", nil))
+}
+
+func (h *MediaHandler) ReorderMedia(c *gin.Context) {
+	id, ok := h.manageAccess(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	list, err := h.media.ReorderMedia(id, &req)
+	if err != nil {
+		mediaError(c, err, "UPDATE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Media reordered successfully", list))
+}
+
+// manageAccess parses the company ID and checks the caller may manage it
+func (h *MediaHandler) manageAccess(c *gin.Context) (uint, bool) {
+	id, ok := idParam(c, "Invalid company ID")
+	if !ok {
+		return 0, false
+	}
+
+	if !companyAccess(c, h.memberships, id, models.MemberPermissionManageCompany) {
+		return 0, false
+	}
+	return id, true
+}
+
+func mediaIDParam(c *gin.Context) (uint, bool) {
+	mediaID, err := strconv.ParseUint(c.Param("media_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid media ID", "INVALID_ID", nil))
+		return 0, false
+	}
+	return uint(mediaID), true
+}
+
+func mediaError(c *gin.Context, err error, code string) {
+	switch {
+	case errors.Is(err, services.ErrCompanyNotFound), errors.Is(err, services.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrMediaLimitReached):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "MEDIA_LIMIT_REACHED", nil))
+	case errors.Is(err, services.ErrInvalidMediaOrder):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), code, nil))
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"jobfair-company-service/internal/middleware"
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// companyMember resolves the company the caller acts for and checks that
+// their member role grants permission. On failure the error response is
+// written and false is returned.
+func companyMember(c *gin.Context, memberships *services.MembershipService, permission models.MemberPermission) (*models.CompanyMember, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return nil, false
+	}
+
+	member, err := memberships.Authorize(userID.(uint), permission)
+	switch {
+	case errors.Is(err, services.ErrNotCompanyMember):
+		c.JSON(http.StatusNotFound, models.ErrorResponse("Company not found", "NOT_FOUND", nil))
+		return nil, false
+	case errors.Is(err, services.ErrInsufficientMemberRole):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+		return nil, false
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to resolve company membership", "SERVER_ERROR", nil))
+		return nil, false
+	}
+
+	return member, true
+}
+
+// companyAccess allows members of companyID whose role grants permission.
+// Platform admins may only view, their changes go through the audited
+// /admin routes.
+func companyAccess(c *gin.Context, memberships *services.MembershipService, companyID uint, permission models.MemberPermission) bool {
+	if c.GetString("user_type") == middleware.RoleAdmin {
+		if permission == models.MemberPermissionViewCompany {
+			return true
+		}
+		c.JSON(http.StatusForbidden, models.ErrorResponse("Admins change companies through the admin endpoints", "FORBIDDEN", nil))
+		return false
+	}
+
+	member, ok := companyMember(c, memberships, permission)
+	if !ok {
+		return false
+	}
+
+	if member.CompanyID != companyID {
+		c.JSON(http.StatusForbidden, models.ErrorResponse("You do not have access to this company", "FORBIDDEN", nil))
+		return false
+	}
+
+	return true
+}
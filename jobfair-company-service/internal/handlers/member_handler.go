@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MemberHandler struct {
+	memberships *services.MembershipService
+}
+
+func NewMemberHandler(memberships *services.MembershipService) *MemberHandler {
+	return &MemberHandler{memberships: memberships}
+}
+
+func (h *MemberHandler) ListMembers(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
+		return
+	}
+
+	members, err := h.memberships.ListMembers(member.CompanyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve members", "SERVER_ERROR", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Members retrieved successfully", members))
+}
+
+func (h *MemberHandler) UpdateMemberRole(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageMembers)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid member ID", "INVALID_ID", nil))
+		return
+	}
+
+	var req models.UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	updated, err := h.memberships.UpdateMemberRole(member, uint(id), req.Role)
+	if err != nil {
+		memberError(c, err, "UPDATE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Member role updated successfully", updated))
+}
+
+func (h *MemberHandler) RemoveMember(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageMembers)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid member ID", "INVALID_ID", nil))
+		return
+	}
+
+	if err := h.memberships.RemoveMember(member, uint(id)); err != nil {
+		memberError(c, err, "DELETE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Member removed successfully", nil))
+}
+
+func (h *MemberHandler) InviteMember(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageMembers)
+	if !ok {
+		return
+	}
+
+	var req models.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	invitation, err := h.memberships.InviteMember(member, &req)
+	if err != nil {
+		memberError(c, err, "INVITE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("Invitation sent successfully", invitation))
+}
+
+func (h *MemberHandler) ListInvitations(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageMembers)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.memberships.ListInvitations(member.CompanyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve invitations", "SERVER_ERROR", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Invitations retrieved successfully", invitations))
+}
+
+func (h *MemberHandler) RevokeInvitation(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageMembers)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid invitation ID", "INVALID_ID", nil))
+		return
+	}
+
+	if err := h.memberships.RevokeInvitation(member.CompanyID, uint(id)); err != nil {
+		memberError(c, err, "REVOKE_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Invitation revoked successfully", nil))
+}
+
+// AcceptInvitation adds the caller to the company that invited them
+func (h *MemberHandler) AcceptInvitation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse("Unauthorized", "UNAUTHORIZED", nil))
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	member, err := h.memberships.AcceptInvitation(userID.(uint), req.Token)
+	if err != nil {
+		memberError(c, err, "ACCEPT_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Invitation accepted successfully", member))
+}
+
+func memberError(c *gin.Context, err error, code string) {
+	switch {
+	case errors.Is(err, services.ErrMemberNotFound), errors.Is(err, services.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrInsufficientMemberRole), errors.Is(err, services.ErrCannotModifyOwner):
+		c.JSON(http.StatusForbidden, models.ErrorResponse(err.Error(), "FORBIDDEN", nil))
+	case errors.Is(err, services.ErrAlreadyCompanyMember):
+		c.JSON(http.StatusConflict, models.ErrorResponse(err.Error(), "ALREADY_MEMBER", nil))
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), code, nil))
+	}
+}
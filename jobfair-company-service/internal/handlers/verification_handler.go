@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/services"
+	"jobfair-company-service/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VerificationHandler struct {
+	memberships  *services.MembershipService
+	verification *services.VerificationService
+}
+
+func NewVerificationHandler(memberships *services.MembershipService, verification *services.VerificationService) *VerificationHandler {
+	return &VerificationHandler{
+		memberships:  memberships,
+		verification: verification,
+	}
+}
+
+// SubmitRequest accepts a multipart form with an optional notes field and
+// files under business_license, tax_id, deed_of_establishment or other
+func (h *VerificationHandler) SubmitRequest(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionManageCompany)
+	if !ok {
+		return
+	}
+
+	var req models.SubmitVerificationRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(services.ErrVerificationDocumentsRequired.Error(), "VALIDATION_ERROR", nil))
+		return
+	}
+
+	request, err := h.verification.SubmitRequest(member.CompanyID, member.UserID, &req, form.File)
+	if err != nil {
+		verificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse("Verification request submitted successfully", request))
+}
+
+// GetMyRequest returns the latest request of the caller's company
+func (h *VerificationHandler) GetMyRequest(c *gin.Context) {
+	member, ok := companyMember(c, h.memberships, models.MemberPermissionViewCompany)
+	if !ok {
+		return
+	}
+
+	request, err := h.verification.GetLatestRequest(member.CompanyID)
+	if err != nil {
+		verificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request retrieved successfully", request))
+}
+
+// ListRequests supports status, page and limit. Pending requests are the
+// review queue.
+func (h *VerificationHandler) ListRequests(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	params := utils.NewPaginationParams(page, limit)
+
+	requests, total, err := h.verification.ListRequests(c.Query("status"), params.Limit, params.GetOffset())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse("Failed to retrieve verification requests", "SERVER_ERROR", nil))
+		return
+	}
+
+	pagination := models.PaginationMeta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: utils.CalculateTotalPages(total, params.Limit),
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedSuccessResponse("Verification requests retrieved successfully", requests, pagination))
+}
+
+func (h *VerificationHandler) GetRequest(c *gin.Context) {
+	id, ok := idParam(c, "Invalid verification request ID")
+	if !ok {
+		return
+	}
+
+	request, err := h.verification.GetRequest(id)
+	if err != nil {
+		verificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request retrieved successfully", request))
+}
+
+// DownloadDocument streams a submitted document to the reviewing admin
+func (h *VerificationHandler) DownloadDocument(c *gin.Context) {
+	id, ok := idParam(c, "Invalid verification request ID")
+	if !ok {
+		return
+	}
+
+	documentID, err := strconv.ParseUint(c.Param("document_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid document ID", "INVALID_ID", nil))
+		return
+	}
+
+	path, name, err := h.verification.DocumentPath(id, uint(documentID))
+	if err != nil {
+		verificationError(c, err)
+		return
+	}
+
+	c.FileAttachment(path, name)
+}
+
+func (h *VerificationHandler) ApproveRequest(c *gin.Context) {
+	id, ok := idParam(c, "Invalid verification request ID")
+	if !ok {
+		return
+	}
+
+	var req models.ApproveVerificationRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	request, err := h.verification.ApproveRequest(c.GetUint("user_id"), id, &req, c.ClientIP())
+	if err != nil {
+		verificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request approved", request))
+}
+
+func (h *VerificationHandler) RejectRequest(c *gin.Context) {
+	id, ok := idParam(c, "Invalid verification request ID")
+	if !ok {
+		return
+	}
+
+	var req models.RejectVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse("Invalid request", "VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	request, err := h.verification.RejectRequest(c.GetUint("user_id"), id, req.Reason, c.ClientIP())
+	if err != nil {
+		verificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse("Verification request rejected", request))
+}
+
+func verificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCompanyNotFound),
+		errors.Is(err, services.ErrVerificationRequestNotFound),
+		errors.Is(err, services.ErrVerificationDocumentNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse(err.Error(), "NOT_FOUND", nil))
+	case errors.Is(err, services.ErrCompanyAlreadyVerified),
+		errors.Is(err, services.ErrVerificationPending),
+		errors.Is(err, services.ErrVerificationNotPending):
+		c.JSON(http.StatusConflict, models.ErrorResponse(err.Error(), "CONFLICT", nil))
+	case errors.Is(err, services.ErrVerificationDocumentsRequired),
+		errors.Is(err, services.ErrTooManyVerificationDocuments):
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VALIDATION_ERROR", nil))
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(err.Error(), "VERIFICATION_FAILED", nil))
+	}
+}
@@ -1,14 +1,22 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"jobfair-shared-libs/jwks"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWTMiddleware(jwtSecret string) gin.HandlerFunc {
+// JWTMiddleware memverifikasi access token RS256 dengan public key dari JWKS
+// auth service. Jika sessions tidak nil, token dari sesi yang sudah di-revoke
+// di auth service juga ditolak.
+func JWTMiddleware(keys *jwks.KeySet, sessions *SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Ambil header Authorization
 		authHeader := c.GetHeader("Authorization")
@@ -29,14 +37,7 @@ func JWTMiddleware(jwtSecret string) gin.HandlerFunc {
 		tokenString := parts[1]
 
 		// Parse token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validasi metode signing
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrTokenMalformed
-			}
-			// Kembalikan secret key
-			return []byte(jwtSecret), nil
-		})
+		token, err := jwt.Parse(tokenString, keys.KeyFunc)
 
 		if err != nil || !token.Valid {
 			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Invalid or expired token"})
@@ -54,8 +55,74 @@ func JWTMiddleware(jwtSecret string) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
+
+			// user_type dipakai untuk membatasi endpoint khusus job seeker
+			if userType, ok := claims["user_type"].(string); ok {
+				c.Set("user_type", userType)
+			}
+
+			// Token lama tanpa sid tetap diterima sampai kadaluarsa
+			if sessionID, ok := claims["sid"].(string); ok && !checkSession(c, sessions, sessionID) {
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// GatewayAuthMiddleware mempercayai header X-User-ID / X-User-Type dari API
+// gateway, tapi hanya jika X-Gateway-Secret cocok. Request lain (misalnya yang
+// langsung ke service) tetap diverifikasi lewat JWTMiddleware.
+func GatewayAuthMiddleware(gatewaySecret string, keys *jwks.KeySet, sessions *SessionChecker) gin.HandlerFunc {
+	jwtMiddleware := JWTMiddleware(keys, sessions)
+
+	return func(c *gin.Context) {
+		secret := c.GetHeader("X-Gateway-Secret")
+		if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(gatewaySecret)) != 1 {
+			jwtMiddleware(c)
+			return
+		}
+
+		userID, err := strconv.ParseUint(c.GetHeader("X-User-ID"), 10, 32)
+		if err != nil || userID == 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Missing or invalid X-User-ID header"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", uint(userID))
+		if userType := c.GetHeader("X-User-Type"); userType != "" {
+			c.Set("user_type", userType)
+		}
+
+		if sessionID := c.GetHeader("X-Session-ID"); sessionID != "" && !checkSession(c, sessions, sessionID) {
+			return
 		}
 
 		c.Next()
 	}
 }
+
+// checkSession menolak request jika sesinya sudah di-revoke. Jika auth service
+// tidak bisa dihubungi request ditolak dengan 503, bukan diloloskan.
+func checkSession(c *gin.Context, sessions *SessionChecker, sessionID string) bool {
+	if sessions == nil {
+		return true
+	}
+
+	active, err := sessions.IsActive(sessionID)
+	if err != nil {
+		log.Printf("Session check failed for %s: %v", sessionID, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"success": false, "message": "Unable to verify session"})
+		c.Abort()
+		return false
+	}
+	if !active {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Session has been revoked"})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
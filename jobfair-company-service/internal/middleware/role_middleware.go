@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Role sama dengan user_type di auth service
+const (
+	RoleJobSeeker = "job_seeker"
+	RoleCompany   = "company"
+	RoleAdmin     = "admin"
+)
+
+type Permission string
+
+const (
+	PermissionJobRead           Permission = "job:read"
+	PermissionJobManage         Permission = "job:manage"
+	PermissionJobApply          Permission = "job:apply"
+	PermissionCompanyCreate     Permission = "company:create"
+	PermissionCompanyOwn        Permission = "company:own"
+	PermissionCompanyMembers    Permission = "company:members"
+	PermissionCompanyManage     Permission = "company:manage"
+	PermissionCompanyAnalytics  Permission = "company:analytics"
+	PermissionApplicationRead   Permission = "application:read"
+	PermissionApplicationReview Permission = "application:review"
+	PermissionApplicationOwn    Permission = "application:own"
+	PermissionPlatformModerate  Permission = "platform:moderate"
+)
+
+// rolePermissions adalah matriks izin: role mana saja yang boleh melakukan
+// tiap aksi. Keanggotaan perusahaan (owner, admin, recruiter, viewer) dan
+// kepemilikan data tetap dicek di handler/service. Admin hanya boleh membaca
+// data perusahaan di sini, perubahan oleh admin lewat /admin/* yang diaudit.
+var rolePermissions = map[Permission][]string{
+	PermissionJobRead:           {RoleJobSeeker, RoleCompany, RoleAdmin},
+	PermissionJobManage:         {RoleCompany},
+	PermissionJobApply:          {RoleJobSeeker},
+	PermissionCompanyCreate:     {RoleCompany},
+	PermissionCompanyOwn:        {RoleCompany},
+	PermissionCompanyMembers:    {RoleCompany},
+	PermissionCompanyManage:     {RoleCompany},
+	PermissionCompanyAnalytics:  {RoleCompany, RoleAdmin},
+	PermissionApplicationRead:   {RoleCompany, RoleAdmin},
+	PermissionApplicationReview: {RoleCompany},
+	PermissionApplicationOwn:    {RoleJobSeeker},
+	PermissionPlatformModerate:  {RoleAdmin},
+}
+
+// RequireRole menolak request jika user_type dari token (atau dari header
+// gateway) tidak termasuk roles. Harus dipasang setelah JWTMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *gin.Context) {
+		if !allowed[c.GetString("user_type")] {
+			c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission adalah RequireRole dengan role dari matriks izin
+func RequirePermission(permission Permission) gin.HandlerFunc {
+	roles, ok := rolePermissions[permission]
+	if !ok {
+		panic("middleware: unknown permission " + string(permission))
+	}
+	return RequireRole(roles...)
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type sessionStatus struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// SessionChecker menanyakan ke auth service apakah sesi (klaim sid) dari
+// access token masih aktif, sehingga token dari sesi yang sudah di-revoke
+// langsung ditolak tanpa menunggu token kadaluarsa. Hasil disimpan sebentar
+// di memori agar tidak setiap request memanggil auth service.
+type SessionChecker struct {
+	authServiceURL string
+	internalSecret string
+	cacheTTL       time.Duration
+	client         *http.Client
+
+	mu    sync.Mutex
+	cache map[string]sessionStatus
+}
+
+func NewSessionChecker(authServiceURL, internalSecret string, cacheTTL time.Duration) *SessionChecker {
+	return &SessionChecker{
+		authServiceURL: authServiceURL,
+		internalSecret: internalSecret,
+		cacheTTL:       cacheTTL,
+		client:         &http.Client{Timeout: 3 * time.Second},
+		cache:          make(map[string]sessionStatus),
+	}
+}
+
+// IsActive mengembalikan status sesi. Error berarti status tidak bisa
+// dipastikan (auth service tidak bisa dihubungi).
+func (s *SessionChecker) IsActive(sessionID string) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	if status, ok := s.cache[sessionID]; ok && now.Before(status.expiresAt) {
+		s.mu.Unlock()
+		return status.active, nil
+	}
+	s.mu.Unlock()
+
+	active, err := s.fetch(sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	// Buang entri kadaluarsa supaya cache tidak terus membesar
+	for id, status := range s.cache {
+		if now.After(status.expiresAt) {
+			delete(s.cache, id)
+		}
+	}
+	s.cache[sessionID] = sessionStatus{active: active, expiresAt: now.Add(s.cacheTTL)}
+	s.mu.Unlock()
+
+	return active, nil
+}
+
+func (s *SessionChecker) fetch(sessionID string) (bool, error) {
+	endpoint := fmt.Sprintf("%s/internal/sessions/%s", s.authServiceURL, url.PathEscape(sessionID))
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("X-Internal-Secret", s.internalSecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("auth service returned status: %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Active bool `json:"active"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, err
+	}
+
+	return body.Data.Active, nil
+}
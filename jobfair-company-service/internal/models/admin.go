@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// AdminAuditLog records one moderation action taken by a platform admin
+type AdminAuditLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AdminID    uint      `json:"admin_id" gorm:"not null;index"`
+	Action     string    `json:"action" gorm:"not null"`
+	TargetType string    `json:"target_type" gorm:"not null"`
+	TargetID   uint      `json:"target_id" gorm:"not null"`
+	Reason     string    `json:"reason,omitempty"`
+	Details    *string   `json:"details,omitempty" gorm:"type:jsonb"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AuditLogFilter struct {
+	AdminID    uint
+	TargetType string
+	TargetID   uint
+	Action     string
+}
+
+type VerifyCompanyRequest struct {
+	// VerificationBadge is shown next to the company name, e.g. "verified"
+	VerificationBadge string `json:"verification_badge" binding:"max=50"`
+	Reason            string `json:"reason" binding:"max=500"`
+}
+
+type ModerationRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type ForceCloseJobRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -46,8 +46,6 @@ type Company struct {
 	Longitude         float64          `json:"longitude,omitempty"`
 	LogoURL           string           `json:"logo_url"`
 	BannerURL         string           `json:"banner_url"`
-	VideoURLs         pq.StringArray   `json:"video_urls" gorm:"type:text[]"`
-	GalleryURLs       pq.StringArray   `json:"gallery_urls" gorm:"type:text[]"`
 	LinkedinURL       string           `json:"linkedin_url"`
 	FacebookURL       string           `json:"facebook_url"`
 	TwitterURL        string           `json:"twitter_url"`
@@ -114,17 +112,11 @@ type CompanyAnalytics struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
-type CompanyMedia struct {
-	ID           uint           `json:"id" gorm:"primaryKey"`
-	CompanyID    uint           `json:"company_id" gorm:"not null;index"`
-	MediaType    string         `json:"media_type"`
-	MediaURL     string         `json:"media_url" gorm:"not null"`
-	Title        string         `json:"title"`
-	Description  string         `json:"description"`
-	DisplayOrder int            `json:"display_order" gorm:"default:0"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+// UploadedFile is the result of a media upload. Images also list the URL of
+// every resized variant (thumbnail, medium, original), URL is the original.
+type UploadedFile struct {
+	URL      string            `json:"url"`
+	Variants map[string]string `json:"variants,omitempty"`
 }
 
 type DashboardStats struct {
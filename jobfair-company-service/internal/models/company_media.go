@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MediaType string
+
+const (
+	MediaTypeGallery MediaType = "gallery"
+	MediaTypeVideo   MediaType = "video"
+)
+
+// MediaLimit is how many items of each type a company may keep
+type MediaLimit struct {
+	Gallery int `json:"gallery"`
+	Video   int `json:"video"`
+}
+
+// MediaLimits per subscription tier, unknown tiers get the free limits
+var MediaLimits = map[SubscriptionTier]MediaLimit{
+	SubscriptionFree:    {Gallery: 5, Video: 1},
+	SubscriptionBasic:   {Gallery: 10, Video: 2},
+	SubscriptionPremium: {Gallery: 25, Video: 5},
+	SubscriptionPro:     {Gallery: 50, Video: 10},
+}
+
+// MediaLimit returns how many items of mediaType the tier allows
+func (t SubscriptionTier) MediaLimit(mediaType MediaType) int {
+	limit, ok := MediaLimits[t]
+	if !ok {
+		limit = MediaLimits[SubscriptionFree]
+	}
+	if mediaType == MediaTypeVideo {
+		return limit.Video
+	}
+	return limit.Gallery
+}
+
+// CompanyMedia is a gallery image or video shown on the company's virtual
+// booth. Items of one type are shown by ascending DisplayOrder.
+type CompanyMedia struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	CompanyID    uint      `json:"company_id" gorm:"not null;index"`
+	MediaType    MediaType `json:"media_type" gorm:"type:varchar(50);not null"`
+	FileName     string    `json:"file_name" gorm:"not null"`
+	FileURL      string    `json:"file_url" gorm:"not null"`
+	FileSize     int64     `json:"file_size"`
+	MimeType     string    `json:"mime_type"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	AltText      string    `json:"alt_text"`
+	DisplayOrder int       `json:"display_order" gorm:"default:0"`
+	// Variants lists the resized copies of a gallery image, only set in the
+	// upload response
+	Variants  map[string]string `json:"variants,omitempty" gorm:"-"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt gorm.DeletedAt    `json:"-" gorm:"index"`
+}
+
+type UploadMediaRequest struct {
+	Title       string `form:"title" binding:"max=255"`
+	Description string `form:"description" binding:"max=2000"`
+	AltText     string `form:"alt_text" binding:"max=255"`
+}
+
+type UpdateMediaRequest struct {
+	Title       *string `json:"title" binding:"omitempty,max=255"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+	AltText     *string `json:"alt_text" binding:"omitempty,max=255"`
+}
+
+// ReorderMediaRequest lists every item of one media type in the new order
+type ReorderMediaRequest struct {
+	MediaType MediaType `json:"media_type" binding:"required,oneof=gallery video"`
+	MediaIDs  []uint    `json:"media_ids" binding:"required"`
+}
+
+// CompanyMediaList is a company's media with the limits of its tier
+type CompanyMediaList struct {
+	Gallery []*CompanyMedia `json:"gallery"`
+	Videos  []*CompanyMedia `json:"videos"`
+	Limits  MediaLimit      `json:"limits"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+type MemberRole string
+
+const (
+	MemberRoleOwner     MemberRole = "owner"
+	MemberRoleAdmin     MemberRole = "admin"
+	MemberRoleRecruiter MemberRole = "recruiter"
+	MemberRoleViewer    MemberRole = "viewer"
+)
+
+// MemberPermission is an action a member can take on their own company.
+type MemberPermission string
+
+const (
+	MemberPermissionViewCompany        MemberPermission = "view_company"
+	MemberPermissionManageCompany      MemberPermission = "manage_company"
+	MemberPermissionManageJobs         MemberPermission = "manage_jobs"
+	MemberPermissionReviewApplications MemberPermission = "review_applications"
+	MemberPermissionManageMembers      MemberPermission = "manage_members"
+)
+
+var memberRolePermissions = map[MemberRole][]MemberPermission{
+	MemberRoleOwner: {
+		MemberPermissionViewCompany,
+		MemberPermissionManageCompany,
+		MemberPermissionManageJobs,
+		MemberPermissionReviewApplications,
+		MemberPermissionManageMembers,
+	},
+	MemberRoleAdmin: {
+		MemberPermissionViewCompany,
+		MemberPermissionManageCompany,
+		MemberPermissionManageJobs,
+		MemberPermissionReviewApplications,
+		MemberPermissionManageMembers,
+	},
+	MemberRoleRecruiter: {
+		MemberPermissionViewCompany,
+		MemberPermissionManageJobs,
+		MemberPermissionReviewApplications,
+	},
+	MemberRoleViewer: {
+		MemberPermissionViewCompany,
+	},
+}
+
+// Can reports whether the role grants the permission.
+func (r MemberRole) Can(permission MemberPermission) bool {
+	for _, p := range memberRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type CompanyMember struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	CompanyID uint       `json:"company_id" gorm:"not null;index"`
+	UserID    uint       `json:"user_id" gorm:"uniqueIndex;not null"`
+	Email     string     `json:"email,omitempty"`
+	Role      MemberRole `json:"role" gorm:"type:varchar(20);not null"`
+	InvitedBy *uint      `json:"invited_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type CompanyInvitation struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	CompanyID  uint       `json:"company_id" gorm:"not null;index"`
+	Email      string     `json:"email" gorm:"not null"`
+	Role       MemberRole `json:"role" gorm:"type:varchar(20);not null"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;not null"`
+	InvitedBy  uint       `json:"invited_by" gorm:"not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy *uint      `json:"accepted_by,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type InviteMemberRequest struct {
+	Email string     `json:"email" binding:"required,email"`
+	Role  MemberRole `json:"role" binding:"required,oneof=admin recruiter viewer"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role MemberRole `json:"role" binding:"required,oneof=admin recruiter viewer"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
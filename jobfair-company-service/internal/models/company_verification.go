@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusApproved VerificationStatus = "approved"
+	VerificationStatusRejected VerificationStatus = "rejected"
+)
+
+// VerificationDocumentTypes are the multipart field names a verification
+// request accepts, each field may carry several files
+var VerificationDocumentTypes = []string{"business_license", "tax_id", "deed_of_establishment", "other"}
+
+type CompanyVerificationRequest struct {
+	ID                uint                          `json:"id" gorm:"primaryKey"`
+	CompanyID         uint                          `json:"company_id" gorm:"not null;index"`
+	SubmittedBy       uint                          `json:"submitted_by" gorm:"not null"`
+	Notes             string                        `json:"notes,omitempty"`
+	Status            VerificationStatus            `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	ReviewedBy        *uint                         `json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time                    `json:"reviewed_at,omitempty"`
+	RejectionReason   string                        `json:"rejection_reason,omitempty"`
+	VerificationBadge string                        `json:"verification_badge,omitempty"`
+	Documents         []CompanyVerificationDocument `json:"documents,omitempty" gorm:"foreignKey:RequestID"`
+	CreatedAt         time.Time                     `json:"created_at"`
+	UpdatedAt         time.Time                     `json:"updated_at"`
+}
+
+type CompanyVerificationDocument struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	RequestID    uint      `json:"request_id" gorm:"not null;index"`
+	DocumentType string    `json:"document_type" gorm:"not null"`
+	FileName     string    `json:"file_name" gorm:"not null"`
+	FilePath     string    `json:"-" gorm:"not null"`
+	FileSize     int64     `json:"file_size"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type SubmitVerificationRequest struct {
+	Notes string `form:"notes" binding:"max=2000"`
+}
+
+type ApproveVerificationRequest struct {
+	// VerificationBadge defaults to "verified"
+	VerificationBadge string `json:"verification_badge" binding:"max=50"`
+}
+
+type RejectVerificationRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
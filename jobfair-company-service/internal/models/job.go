@@ -45,8 +45,29 @@ const (
 	ApplicationStatusInterview   ApplicationStatus = "interview"
 	ApplicationStatusRejected    ApplicationStatus = "rejected"
 	ApplicationStatusApplied     ApplicationStatus = "applied"
+	ApplicationStatusWithdrawn   ApplicationStatus = "withdrawn"
 )
 
+// applicationStatusTransitions lists the statuses each status may move to.
+// Hired, rejected and withdrawn are final.
+var applicationStatusTransitions = map[ApplicationStatus][]ApplicationStatus{
+	ApplicationStatusApplied:     {ApplicationStatusShortlisted, ApplicationStatusInterview, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusShortlisted: {ApplicationStatusInterview, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusInterview:   {ApplicationStatusHired, ApplicationStatusRejected, ApplicationStatusWithdrawn},
+	ApplicationStatusHired:       {},
+	ApplicationStatusRejected:    {},
+	ApplicationStatusWithdrawn:   {},
+}
+
+func (s ApplicationStatus) CanTransitionTo(next ApplicationStatus) bool {
+	for _, allowed := range applicationStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Job struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	CompanyID   uint           `json:"company_id" gorm:"not null;index"`
@@ -72,6 +93,9 @@ type Job struct {
 	ApplicationCount int       `json:"application_count" gorm:"default:0"`
 	ExpiresAt   *time.Time     `json:"expires_at"`
 	PublishedAt *time.Time     `json:"published_at"`
+	// Set when an admin force-closes the job, the company cannot reopen it
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	ModerationReason string     `json:"moderation_reason,omitempty"`
 	Slug        string         `json:"slug" gorm:"index"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -127,13 +151,36 @@ type JobApplication struct {
 	Status        ApplicationStatus `json:"status" gorm:"default:'applied'"`
 	CoverLetter   string            `json:"cover_letter" gorm:"type:text"`
 	ResumeURL     string            `json:"resume_url"`
+	// ResumeID points into the applicant's resume library in the auth
+	// service, the file is fetched through a time-limited download link
+	ResumeID      *uint             `json:"resume_id,omitempty"`
+	// ResumeData is the parsed resume at the time of applying, ResumeSkills
+	// holds its skills in lower case for filtering
+	ResumeData    *ParsedResume     `json:"resume_data,omitempty" gorm:"type:jsonb;serializer:json"`
+	ResumeSkills  pq.StringArray    `json:"-" gorm:"type:text[]"`
 	AppliedAt     time.Time         `json:"applied_at"`
 	ViewedAt      *time.Time        `json:"viewed_at"`
+	WithdrawnAt   *time.Time        `json:"withdrawn_at"`
 	UpdatedAt     time.Time         `json:"updated_at"`
 	Notes         string            `json:"notes" gorm:"type:text"`
 	CreatedAt     time.Time         `json:"created_at"`
 }
 
+type ApplyJobRequest struct {
+	CoverLetter string `form:"cover_letter" json:"cover_letter"`
+	ResumeURL   string `form:"resume_url" json:"resume_url"`
+	// ResumeID picks a resume from the applicant's library, without a file,
+	// resume_id or resume_url the default resume is used
+	ResumeID uint `form:"resume_id" json:"resume_id"`
+}
+
+// ResumeDownloadLink is a time-limited URL to the resume of an application
+type ResumeDownloadLink struct {
+	URL       string `json:"url"`
+	FileName  string `json:"file_name"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
 type JobApplicationDetail struct {
 	ID            uint              `json:"id"`
 	JobID         uint              `json:"job_id"`
@@ -147,7 +194,50 @@ type JobApplicationDetail struct {
 	Experience    string            `json:"experience"`
 	Status        ApplicationStatus `json:"status"`
 	ResumeURL     string            `json:"resume_url"`
+	ResumeID      *uint             `json:"resume_id,omitempty"`
 	CoverLetter   string            `json:"cover_letter"`
+	// Skills, Education and WorkHistory come from the parsed resume
+	Skills        []string          `json:"skills"`
+	Education     []ResumeEducation `json:"education"`
+	WorkHistory   []ResumeExperience `json:"work_history"`
+}
+
+type ApplicationStatusHistory struct {
+	ID            uint               `json:"id" gorm:"primaryKey"`
+	ApplicationID uint               `json:"application_id" gorm:"not null;index"`
+	FromStatus    *ApplicationStatus `json:"from_status"`
+	ToStatus      ApplicationStatus  `json:"to_status" gorm:"not null"`
+	ChangedBy     uint               `json:"changed_by" gorm:"not null"`
+	ChangedByType string             `json:"changed_by_type" gorm:"not null"`
+	Note          string             `json:"note" gorm:"type:text"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+func (ApplicationStatusHistory) TableName() string {
+	return "application_status_history"
+}
+
+type UpdateApplicationStatusRequest struct {
+	Status ApplicationStatus `json:"status" binding:"required"`
+	Note   string            `json:"note"`
+}
+
+type MyApplicationResponse struct {
+	ID             uint              `json:"id"`
+	JobID          uint              `json:"job_id"`
+	JobTitle       string            `json:"job_title"`
+	JobType        JobType           `json:"job_type"`
+	JobLocation    string            `json:"job_location"`
+	CompanyID      uint              `json:"company_id"`
+	CompanyName    string            `json:"company_name"`
+	CompanyLogoURL string            `json:"company_logo_url"`
+	Status         ApplicationStatus `json:"status"`
+	CoverLetter    string            `json:"cover_letter"`
+	ResumeURL      string            `json:"resume_url"`
+	ResumeID       *uint             `json:"resume_id,omitempty"`
+	AppliedAt      time.Time         `json:"applied_at"`
+	WithdrawnAt    *time.Time        `json:"withdrawn_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 }
 
 type JobListResponse struct {
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type JobSort string
+
+const (
+	JobSortRelevance JobSort = "relevance"
+	JobSortRecent    JobSort = "recent"
+	JobSortSalary    JobSort = "salary"
+)
+
+// JobSearchFilter describes a public job board query. Empty fields do not
+// filter.
+type JobSearchFilter struct {
+	Query     string
+	JobTypes  []JobType
+	JobLevels []JobLevel
+	City      string
+	Country   string
+	Industry  string
+	IsRemote  *bool
+	// SalaryMin and SalaryMax match jobs whose salary range overlaps them.
+	// Jobs that hide their salary never match a salary filter.
+	SalaryMin int
+	SalaryMax int
+	Sort      JobSort
+}
+
+// SalaryBucket is a salary range offered as a filter. Max is nil for the
+// open ended top bucket. Applying a bucket as salary_min/salary_max returns
+// exactly the jobs counted for it.
+type SalaryBucket struct {
+	Min int  `json:"min"`
+	Max *int `json:"max,omitempty"`
+}
+
+// SalaryBuckets are the job board salary ranges, in IDR per month
+var SalaryBuckets = []SalaryBucket{
+	{Min: 0, Max: intPtr(5000000)},
+	{Min: 5000000, Max: intPtr(10000000)},
+	{Min: 10000000, Max: intPtr(20000000)},
+	{Min: 20000000, Max: intPtr(35000000)},
+	{Min: 35000000},
+}
+
+func intPtr(v int) *int { return &v }
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type SalaryBucketCount struct {
+	SalaryBucket
+	Count int64 `json:"count"`
+}
+
+// JobFacets holds the number of matching jobs per filter value. Each facet
+// is counted with every filter of the query applied except its own, so the
+// counts show what selecting another value would return.
+type JobFacets struct {
+	JobType  []FacetCount        `json:"job_type"`
+	JobLevel []FacetCount        `json:"job_level"`
+	City     []FacetCount        `json:"city"`
+	Country  []FacetCount        `json:"country"`
+	IsRemote []FacetCount        `json:"is_remote"`
+	Industry []FacetCount        `json:"industry"`
+	Salary   []SalaryBucketCount `json:"salary"`
+}
+
+// JobSearchResponse is a paginated job board result with its facet counts
+type JobSearchResponse struct {
+	PaginatedResponse
+	Facets *JobFacets `json:"facets,omitempty"`
+}
+
+// PublicJob is a job as shown on the public job board. Internal fields
+// (status, metrics, moderation) are left out and the salary is only set
+// when the company chose to show it.
+type PublicJob struct {
+	ID               uint              `json:"id"`
+	Slug             string            `json:"slug"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description,omitempty"`
+	Requirements     string            `json:"requirements,omitempty"`
+	Responsibilities string            `json:"responsibilities,omitempty"`
+	JobType          JobType           `json:"job_type"`
+	JobLevel         JobLevel          `json:"job_level"`
+	Location         string            `json:"location"`
+	City             string            `json:"city"`
+	Country          string            `json:"country"`
+	IsRemote         bool              `json:"is_remote"`
+	SalaryMin        *int              `json:"salary_min,omitempty"`
+	SalaryMax        *int              `json:"salary_max,omitempty"`
+	SalaryCurrency   string            `json:"salary_currency,omitempty"`
+	Skills           pq.StringArray    `json:"skills"`
+	Benefits         pq.StringArray    `json:"benefits,omitempty"`
+	Positions        int               `json:"positions"`
+	PublishedAt      *time.Time        `json:"published_at"`
+	ExpiresAt        *time.Time        `json:"expires_at"`
+	Company          *PublicJobCompany `json:"company"`
+}
+
+type PublicJobCompany struct {
+	ID                uint   `json:"id"`
+	Name              string `json:"name"`
+	Slug              string `json:"slug"`
+	LogoURL           string `json:"logo_url"`
+	Industry          string `json:"industry"`
+	IsVerified        bool   `json:"is_verified"`
+	VerificationBadge string `json:"verification_badge,omitempty"`
+}
+
+// NewPublicJob converts a job for the job board. Long text fields are only
+// included when detail is true.
+func NewPublicJob(job *Job, company *Company, detail bool) PublicJob {
+	public := PublicJob{
+		ID:          job.ID,
+		Slug:        job.Slug,
+		Title:       job.Title,
+		JobType:     job.JobType,
+		JobLevel:    job.JobLevel,
+		Location:    job.Location,
+		City:        job.City,
+		Country:     job.Country,
+		IsRemote:    job.IsRemote,
+		Skills:      job.Skills,
+		Positions:   job.Positions,
+		PublishedAt: job.PublishedAt,
+		ExpiresAt:   job.ExpiresAt,
+	}
+
+	if job.ShowSalary {
+		salaryMin, salaryMax := job.SalaryMin, job.SalaryMax
+		public.SalaryMin = &salaryMin
+		public.SalaryMax = &salaryMax
+		public.SalaryCurrency = job.SalaryCurrency
+	}
+
+	if detail {
+		public.Description = job.Description
+		public.Requirements = job.Requirements
+		public.Responsibilities = job.Responsibilities
+		public.Benefits = job.Benefits
+	}
+
+	if company != nil {
+		public.Company = &PublicJobCompany{
+			ID:                company.ID,
+			Name:              company.Name,
+			Slug:              company.Slug,
+			LogoURL:           company.LogoURL,
+			Industry:          company.Industry,
+			IsVerified:        company.IsVerified,
+			VerificationBadge: company.VerificationBadge,
+		}
+	}
+
+	return public
+}
@@ -0,0 +1,62 @@
+package models
+
+import "testing"
+
+func TestApplicationStatusCanTransitionTo(t *testing.T) {
+	statuses := []ApplicationStatus{
+		ApplicationStatusApplied,
+		ApplicationStatusShortlisted,
+		ApplicationStatusInterview,
+		ApplicationStatusHired,
+		ApplicationStatusRejected,
+		ApplicationStatusWithdrawn,
+	}
+
+	// allowed[from] lists every status from may move to, all other pairs
+	// must be rejected
+	allowed := map[ApplicationStatus]map[ApplicationStatus]bool{
+		ApplicationStatusApplied: {
+			ApplicationStatusShortlisted: true,
+			ApplicationStatusInterview:   true,
+			ApplicationStatusRejected:    true,
+			ApplicationStatusWithdrawn:   true,
+		},
+		ApplicationStatusShortlisted: {
+			ApplicationStatusInterview: true,
+			ApplicationStatusRejected:  true,
+			ApplicationStatusWithdrawn: true,
+		},
+		ApplicationStatusInterview: {
+			ApplicationStatusHired:     true,
+			ApplicationStatusRejected:  true,
+			ApplicationStatusWithdrawn: true,
+		},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[from][to]
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s -> %s: got %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestApplicationStatusCanTransitionToUnknown(t *testing.T) {
+	tests := []struct {
+		from ApplicationStatus
+		to   ApplicationStatus
+	}{
+		{"unknown", ApplicationStatusShortlisted},
+		{ApplicationStatusApplied, "unknown"},
+		{ApplicationStatusApplied, ""},
+		{"", ApplicationStatusApplied},
+	}
+
+	for _, tt := range tests {
+		if tt.from.CanTransitionTo(tt.to) {
+			t.Errorf("%q -> %q: transition allowed", tt.from, tt.to)
+		}
+	}
+}
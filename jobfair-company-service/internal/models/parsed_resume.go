@@ -0,0 +1,41 @@
+package models
+
+// ParsedResume is the structured record the auth service extracts from a
+// resume's text when it is stored in the resume library, files uploaded with
+// an application included. Parsing is heuristic, fields it could not
+// recognise are left empty.
+type ParsedResume struct {
+	Contact    ResumeContact      `json:"contact"`
+	Skills     []string           `json:"skills"`
+	Education  []ResumeEducation  `json:"education"`
+	Experience []ResumeExperience `json:"experience"`
+	// TotalExperienceMonths counts the months covered by the work history,
+	// overlapping positions are counted once
+	TotalExperienceMonths int `json:"total_experience_months"`
+}
+
+type ResumeContact struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	LinkedIn string `json:"linkedin,omitempty"`
+}
+
+type ResumeEducation struct {
+	Institution string `json:"institution,omitempty"`
+	Degree      string `json:"degree,omitempty"`
+	StartYear   int    `json:"start_year,omitempty"`
+	EndYear     int    `json:"end_year,omitempty"`
+	GPA         string `json:"gpa,omitempty"`
+}
+
+type ResumeExperience struct {
+	Title   string `json:"title,omitempty"`
+	Company string `json:"company,omitempty"`
+	// StartDate and EndDate are YYYY-MM, or YYYY when the month is not given
+	StartDate      string `json:"start_date,omitempty"`
+	EndDate        string `json:"end_date,omitempty"`
+	IsCurrent      bool   `json:"is_current"`
+	DurationMonths int    `json:"duration_months"`
+	Description    string `json:"description,omitempty"`
+}
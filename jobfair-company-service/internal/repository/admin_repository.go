@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AdminRepository applies moderation changes. Each change is stored in the
+// same transaction as its audit entry, so no action is applied unaudited.
+type AdminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+// SetCompanyVerified verifies (with a badge) or unverifies a company
+func (r *AdminRepository) SetCompanyVerified(companyID uint, verified bool, badge string, entry *models.AdminAuditLog) error {
+	updates := map[string]interface{}{
+		"is_verified":        verified,
+		"verified_at":        nil,
+		"verification_badge": "",
+	}
+	if verified {
+		updates["verified_at"] = time.Now()
+		updates["verification_badge"] = badge
+	}
+
+	return r.updateWithAudit(&models.Company{}, companyID, updates, entry)
+}
+
+func (r *AdminRepository) SetCompanyFeatured(companyID uint, featured bool, entry *models.AdminAuditLog) error {
+	return r.updateWithAudit(&models.Company{}, companyID, map[string]interface{}{"is_featured": featured}, entry)
+}
+
+// ForceCloseJob closes the job and marks it as moderated
+func (r *AdminRepository) ForceCloseJob(jobID uint, reason string, entry *models.AdminAuditLog) error {
+	return r.updateWithAudit(&models.Job{}, jobID, map[string]interface{}{
+		"status":            models.JobStatusClosed,
+		"moderated_at":      time.Now(),
+		"moderation_reason": reason,
+	}, entry)
+}
+
+func (r *AdminRepository) ListAuditLogs(filter models.AuditLogFilter, limit, offset int) ([]*models.AdminAuditLog, int64, error) {
+	var logs []*models.AdminAuditLog
+	var total int64
+
+	query := r.db.Model(&models.AdminAuditLog{})
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Limit(limit).Offset(offset).Order("created_at DESC, id DESC").Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+func (r *AdminRepository) updateWithAudit(model interface{}, id uint, updates map[string]interface{}, entry *models.AdminAuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(model).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(entry).Error
+	})
+}
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"jobfair-company-service/internal/models"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -21,6 +25,48 @@ func (r *ApplicationRepository) Create(application *models.JobApplication) (*mod
 	return application, nil
 }
 
+// CreateForJob inserts the application with its initial history entry and
+// bumps the job and company application counters in a single transaction.
+func (r *ApplicationRepository) CreateForJob(application *models.JobApplication) (*models.JobApplication, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(application).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&models.ApplicationStatusHistory{
+			ApplicationID: application.ID,
+			ToStatus:      application.Status,
+			ChangedBy:     application.UserID,
+			ChangedByType: "job_seeker",
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Job{}).Where("id = ?", application.JobID).
+			UpdateColumn("application_count", gorm.Expr("application_count + ?", 1)).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.CompanyAnalytics{}).Where("company_id = ?", application.CompanyID).
+			UpdateColumn("applications", gorm.Expr("applications + ?", 1))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return tx.Create(&models.CompanyAnalytics{
+				CompanyID:    application.CompanyID,
+				Applications: 1,
+			}).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
 func (r *ApplicationRepository) GetByID(id uint) (*models.JobApplication, error) {
 	var application models.JobApplication
 	if err := r.db.First(&application, id).Error; err != nil {
@@ -40,9 +86,15 @@ func (r *ApplicationRepository) List(companyID uint, limit, offset int, filters
 	query := r.db.Model(&models.JobApplication{}).Where("company_id = ?", companyID)
 
 	for key, value := range filters {
-		if value != "" && value != nil {
-			query = query.Where(key+" = ?", value)
+		if value == "" || value == nil {
+			continue
 		}
+		if key == "resume_skills" {
+			// Containment, the GIN index on resume_skills serves it
+			query = query.Where("resume_skills @> ?", pq.Array(value))
+			continue
+		}
+		query = query.Where(key+" = ?", value)
 	}
 
 	if err := query.Count(&total).Error; err != nil {
@@ -64,6 +116,14 @@ func (r *ApplicationRepository) GetByJobID(jobID uint) ([]*models.JobApplication
 	return applications, nil
 }
 
+func (r *ApplicationRepository) GetByJobAndUser(jobID, userID uint) (*models.JobApplication, error) {
+	var application models.JobApplication
+	if err := r.db.Where("job_id = ? AND user_id = ?", jobID, userID).First(&application).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
 func (r *ApplicationRepository) GetByCompanyID(companyID uint) ([]*models.JobApplication, error) {
 	var applications []*models.JobApplication
 	if err := r.db.Where("company_id = ?", companyID).Order("applied_at DESC").Find(&applications).Error; err != nil {
@@ -96,6 +156,89 @@ func (r *ApplicationRepository) CountByStatus(companyID uint, status models.Appl
 	return count, nil
 }
 
-func (r *ApplicationRepository) UpdateStatus(id uint, status models.ApplicationStatus) error {
-	return r.db.Model(&models.JobApplication{}).Where("id = ?", id).Update("status", status).Error
-}
\ No newline at end of file
+// UpdateStatus changes the application status and records the transition in
+// the status history.
+func (r *ApplicationRepository) UpdateStatus(id uint, status models.ApplicationStatus, history *models.ApplicationStatusHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status": status,
+		}
+		if status == models.ApplicationStatusWithdrawn {
+			updates["withdrawn_at"] = time.Now()
+		}
+
+		// Guard against a concurrent change since the caller read the status
+		query := tx.Model(&models.JobApplication{}).Where("id = ?", id)
+		if history.FromStatus != nil {
+			query = query.Where("status = ?", *history.FromStatus)
+		}
+
+		result := query.Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("application status was changed concurrently, please retry")
+		}
+
+		history.ApplicationID = id
+		history.ToStatus = status
+		return tx.Create(history).Error
+	})
+}
+
+func (r *ApplicationRepository) GetStatusHistory(id uint) ([]*models.ApplicationStatusHistory, error) {
+	var history []*models.ApplicationStatusHistory
+	if err := r.db.Where("application_id = ?", id).Order("created_at ASC, id ASC").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+const myApplicationColumns = `job_applications.id, job_applications.job_id, jobs.title AS job_title,
+	jobs.job_type, jobs.location AS job_location, job_applications.company_id,
+	companies.name AS company_name, companies.logo_url AS company_logo_url,
+	job_applications.status, job_applications.cover_letter, job_applications.resume_url,
+	job_applications.resume_id, job_applications.applied_at, job_applications.withdrawn_at, job_applications.updated_at`
+
+func (r *ApplicationRepository) myApplicationsQuery(userID uint) *gorm.DB {
+	return r.db.Table("job_applications").
+		Joins("JOIN jobs ON jobs.id = job_applications.job_id").
+		Joins("JOIN companies ON companies.id = job_applications.company_id").
+		Where("job_applications.user_id = ?", userID)
+}
+
+func (r *ApplicationRepository) ListByUserID(userID uint, limit, offset int, filters map[string]interface{}) ([]*models.MyApplicationResponse, int64, error) {
+	var applications []*models.MyApplicationResponse
+	var total int64
+
+	query := r.myApplicationsQuery(userID)
+
+	for key, value := range filters {
+		if value != "" && value != nil {
+			query = query.Where("job_applications."+key+" = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Select(myApplicationColumns).Limit(limit).Offset(offset).Order("job_applications.applied_at DESC").Scan(&applications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return applications, total, nil
+}
+
+func (r *ApplicationRepository) GetByIDForUser(id uint, userID uint) (*models.MyApplicationResponse, error) {
+	var application models.MyApplicationResponse
+	result := r.myApplicationsQuery(userID).Select(myApplicationColumns).Where("job_applications.id = ?", id).Limit(1).Scan(&application)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &application, nil
+}
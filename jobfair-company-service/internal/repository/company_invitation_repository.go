@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvitationUnavailable means the invitation was accepted, revoked or
+// expired, possibly by a concurrent request.
+var ErrInvitationUnavailable = errors.New("invitation is no longer valid")
+
+type CompanyInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyInvitationRepository(db *gorm.DB) *CompanyInvitationRepository {
+	return &CompanyInvitationRepository{db: db}
+}
+
+// Create stores a new invitation, revoking any pending one for the same email
+// so only the latest link works.
+func (r *CompanyInvitationRepository) Create(invitation *models.CompanyInvitation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CompanyInvitation{}).
+			Where("company_id = ? AND LOWER(email) = ? AND accepted_at IS NULL AND revoked_at IS NULL",
+				invitation.CompanyID, strings.ToLower(invitation.Email)).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(invitation).Error
+	})
+}
+
+func (r *CompanyInvitationRepository) GetByTokenHash(tokenHash string) (*models.CompanyInvitation, error) {
+	var invitation models.CompanyInvitation
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ListPending returns the invitations of a company that can still be accepted
+func (r *CompanyInvitationRepository) ListPending(companyID uint) ([]*models.CompanyInvitation, error) {
+	var invitations []*models.CompanyInvitation
+	err := r.db.Where("company_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", companyID, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// Revoke cancels a pending invitation. Returns false if there was none.
+func (r *CompanyInvitationRepository) Revoke(companyID, id uint) (bool, error) {
+	result := r.db.Model(&models.CompanyInvitation{}).
+		Where("id = ? AND company_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id, companyID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// Accept marks the invitation as used and creates the membership in one
+// transaction. The conditional update makes a token usable only once.
+func (r *CompanyInvitationRepository) Accept(invitation *models.CompanyInvitation, member *models.CompanyMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.CompanyInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", invitation.ID, now).
+			Updates(map[string]interface{}{"accepted_at": now, "accepted_by": member.UserID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationUnavailable
+		}
+
+		return tx.Create(member).Error
+	})
+}
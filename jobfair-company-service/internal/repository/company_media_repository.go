@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrMediaLimitReached is returned when the company already has as many
+	// items of a type as its tier allows
+	ErrMediaLimitReached = errors.New("media limit of the subscription tier reached")
+	// ErrInvalidMediaOrder is returned when a reorder does not list exactly
+	// the company's items of that type
+	ErrInvalidMediaOrder = errors.New("media_ids must list every item of the media type exactly once")
+)
+
+type CompanyMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyMediaRepository(db *gorm.DB) *CompanyMediaRepository {
+	return &CompanyMediaRepository{db: db}
+}
+
+// CreateWithinLimit appends media after the company's other items of its
+// type. The company row is locked so concurrent uploads cannot both pass
+// the limit check.
+func (r *CompanyMediaRepository) CreateWithinLimit(media *models.CompanyMedia, limit int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var company models.Company
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&company, media.CompanyID).Error; err != nil {
+			return err
+		}
+
+		var stats struct {
+			Count     int64
+			NextOrder int
+		}
+		err := tx.Model(&models.CompanyMedia{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(display_order) + 1, 0) AS next_order").
+			Where("company_id = ? AND media_type = ?", media.CompanyID, media.MediaType).
+			Scan(&stats).Error
+		if err != nil {
+			return err
+		}
+		if stats.Count >= int64(limit) {
+			return ErrMediaLimitReached
+		}
+
+		media.DisplayOrder = stats.NextOrder
+		return tx.Create(media).Error
+	})
+}
+
+// GetByID returns an item only when it belongs to the company
+func (r *CompanyMediaRepository) GetByID(companyID, id uint) (*models.CompanyMedia, error) {
+	var media models.CompanyMedia
+	if err := r.db.Where("company_id = ?", companyID).First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *CompanyMediaRepository) ListByCompany(companyID uint) ([]*models.CompanyMedia, error) {
+	var media []*models.CompanyMedia
+	err := r.db.Where("company_id = ?", companyID).
+		Order("media_type ASC, display_order ASC, id ASC").
+		Find(&media).Error
+	return media, err
+}
+
+// UpdateCaption saves the title, description and alt text of an item
+func (r *CompanyMediaRepository) UpdateCaption(media *models.CompanyMedia) error {
+	return r.db.Model(media).Select("title", "description", "alt_text").Updates(media).Error
+}
+
+func (r *CompanyMediaRepository) Delete(media *models.CompanyMedia) error {
+	return r.db.Delete(media).Error
+}
+
+// CountByURL counts the company's items that point at fileURL. Uploads are
+// content addressed, so the same file uploaded twice is shared.
+func (r *CompanyMediaRepository) CountByURL(companyID uint, fileURL string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyMedia{}).
+		Where("company_id = ? AND file_url = ?", companyID, fileURL).
+		Count(&count).Error
+	return count, err
+}
+
+// Reorder sets display_order of the company's items of mediaType to their
+// position in ids
+func (r *CompanyMediaRepository) Reorder(companyID uint, mediaType models.MediaType, ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing []uint
+		err := tx.Model(&models.CompanyMedia{}).
+			Where("company_id = ? AND media_type = ?", companyID, mediaType).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Pluck("id", &existing).Error
+		if err != nil {
+			return err
+		}
+
+		if len(existing) != len(ids) {
+			return ErrInvalidMediaOrder
+		}
+		remaining := make(map[uint]bool, len(existing))
+		for _, id := range existing {
+			remaining[id] = true
+		}
+		for _, id := range ids {
+			if !remaining[id] {
+				return ErrInvalidMediaOrder
+			}
+			delete(remaining, id)
+		}
+
+		for position, id := range ids {
+			err := tx.Model(&models.CompanyMedia{}).Where("id = ?", id).Update("display_order", position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
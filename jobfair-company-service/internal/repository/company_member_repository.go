@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CompanyMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyMemberRepository(db *gorm.DB) *CompanyMemberRepository {
+	return &CompanyMemberRepository{db: db}
+}
+
+func (r *CompanyMemberRepository) GetByID(id uint) (*models.CompanyMember, error) {
+	var member models.CompanyMember
+	if err := r.db.First(&member, id).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *CompanyMemberRepository) GetByUserID(userID uint) (*models.CompanyMember, error) {
+	var member models.CompanyMember
+	if err := r.db.Where("user_id = ?", userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *CompanyMemberRepository) ListByCompany(companyID uint) ([]*models.CompanyMember, error) {
+	var members []*models.CompanyMember
+	err := r.db.Where("company_id = ?", companyID).Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+func (r *CompanyMemberRepository) UpdateRole(id uint, role models.MemberRole) error {
+	return r.db.Model(&models.CompanyMember{}).Where("id = ?", id).Update("role", role).Error
+}
+
+func (r *CompanyMemberRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CompanyMember{}, id).Error
+}
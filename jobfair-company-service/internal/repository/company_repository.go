@@ -23,6 +23,25 @@ func (r *CompanyRepository) Create(company *models.Company) (*models.Company, er
 	return company, nil
 }
 
+// CreateWithOwner inserts the company together with its owner membership
+func (r *CompanyRepository) CreateWithOwner(company *models.Company) (*models.Company, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(company).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.CompanyMember{
+			CompanyID: company.ID,
+			UserID:    company.UserID,
+			Role:      models.MemberRoleOwner,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
 func (r *CompanyRepository) GetByID(id uint) (*models.Company, error) {
 	var company models.Company
 	if err := r.db.First(&company, id).Error; err != nil {
@@ -31,6 +50,17 @@ func (r *CompanyRepository) GetByID(id uint) (*models.Company, error) {
 	return &company, nil
 }
 
+func (r *CompanyRepository) GetByIDs(ids []uint) ([]*models.Company, error) {
+	var companies []*models.Company
+	if len(ids) == 0 {
+		return companies, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
 func (r *CompanyRepository) GetByUserID(userID uint) (*models.Company, error) {
 	var company models.Company
 	if err := r.db.Where("user_id = ?", userID).First(&company).Error; err != nil {
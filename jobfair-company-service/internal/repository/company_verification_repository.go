@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"jobfair-company-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrVerificationNotPending is returned when a request was already reviewed
+var ErrVerificationNotPending = errors.New("verification request is not pending")
+
+type CompanyVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyVerificationRepository(db *gorm.DB) *CompanyVerificationRepository {
+	return &CompanyVerificationRepository{db: db}
+}
+
+// Create stores the request together with its documents
+func (r *CompanyVerificationRepository) Create(request *models.CompanyVerificationRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *CompanyVerificationRepository) GetByID(id uint) (*models.CompanyVerificationRequest, error) {
+	var request models.CompanyVerificationRequest
+	if err := r.db.Preload("Documents").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetLatestByCompany returns the most recent request of a company
+func (r *CompanyVerificationRepository) GetLatestByCompany(companyID uint) (*models.CompanyVerificationRequest, error) {
+	var request models.CompanyVerificationRequest
+	if err := r.db.Preload("Documents").
+		Where("company_id = ?", companyID).
+		Order("created_at DESC, id DESC").
+		First(&request).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *CompanyVerificationRepository) HasPending(companyID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CompanyVerificationRequest{}).
+		Where("company_id = ? AND status = ?", companyID, models.VerificationStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *CompanyVerificationRepository) List(status string, limit, offset int) ([]*models.CompanyVerificationRequest, int64, error) {
+	var requests []*models.CompanyVerificationRequest
+	var total int64
+
+	query := r.db.Model(&models.CompanyVerificationRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Oldest first so the review queue is handled in submission order
+	if err := query.Preload("Documents").Limit(limit).Offset(offset).Order("created_at ASC, id ASC").Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+// Approve closes the request and verifies the company in one transaction,
+// together with the audit entry
+func (r *CompanyVerificationRepository) Approve(request *models.CompanyVerificationRequest, reviewerID uint, badge string, entry *models.AdminAuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := r.review(tx, request.ID, map[string]interface{}{
+			"status":             models.VerificationStatusApproved,
+			"reviewed_by":        reviewerID,
+			"reviewed_at":        now,
+			"verification_badge": badge,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Company{}).Where("id = ?", request.CompanyID).Updates(map[string]interface{}{
+			"is_verified":        true,
+			"verified_at":        now,
+			"verification_badge": badge,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *CompanyVerificationRepository) Reject(request *models.CompanyVerificationRequest, reviewerID uint, reason string, entry *models.AdminAuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.review(tx, request.ID, map[string]interface{}{
+			"status":           models.VerificationStatusRejected,
+			"reviewed_by":      reviewerID,
+			"reviewed_at":      time.Now(),
+			"rejection_reason": reason,
+		}); err != nil {
+			return err
+		}
+
+		return tx.Create(entry).Error
+	})
+}
+
+// review only updates requests that are still pending, so two admins
+// reviewing the same request cannot both succeed
+func (r *CompanyVerificationRepository) review(tx *gorm.DB, id uint, updates map[string]interface{}) error {
+	result := tx.Model(&models.CompanyVerificationRequest{}).
+		Where("id = ? AND status = ?", id, models.VerificationStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVerificationNotPending
+	}
+	return nil
+}
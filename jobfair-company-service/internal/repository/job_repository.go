@@ -2,9 +2,11 @@ package repository
 
 import (
 	"jobfair-company-service/internal/models"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepository struct {
@@ -61,6 +63,218 @@ func (r *JobRepository) List(companyID uint, limit, offset int, filters map[stri
 	return jobs, total, nil
 }
 
+// SlugExists reports whether another live job already uses slug
+func (r *JobRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Job{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// publicJobs scopes a query to jobs visible on the public job board
+func (r *JobRepository) publicJobs() *gorm.DB {
+	return r.db.Model(&models.Job{}).
+		Joins("JOIN companies ON companies.id = jobs.company_id AND companies.deleted_at IS NULL").
+		Where("jobs.status = ? AND jobs.published_at IS NOT NULL", models.JobStatusActive).
+		Where("(jobs.expires_at IS NULL OR jobs.expires_at > ?)", time.Now())
+}
+
+// searchPublic applies the filters of a job board query
+func (r *JobRepository) searchPublic(filter models.JobSearchFilter) *gorm.DB {
+	query := r.publicJobs()
+
+	if filter.Query != "" {
+		query = query.Where("jobs.search_vector @@ websearch_to_tsquery('simple', ?)", filter.Query)
+	}
+	if len(filter.JobTypes) > 0 {
+		query = query.Where("jobs.job_type IN ?", filter.JobTypes)
+	}
+	if len(filter.JobLevels) > 0 {
+		query = query.Where("jobs.job_level IN ?", filter.JobLevels)
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(jobs.city) = LOWER(?)", filter.City)
+	}
+	if filter.Country != "" {
+		query = query.Where("LOWER(jobs.country) = LOWER(?)", filter.Country)
+	}
+	if filter.Industry != "" {
+		query = query.Where("LOWER(companies.industry) = LOWER(?)", filter.Industry)
+	}
+	if filter.IsRemote != nil {
+		query = query.Where("jobs.is_remote = ?", *filter.IsRemote)
+	}
+	if filter.SalaryMin > 0 || filter.SalaryMax > 0 {
+		query = query.Where("jobs.show_salary = ?", true)
+	}
+	if filter.SalaryMin > 0 {
+		query = query.Where("jobs.salary_max >= ?", filter.SalaryMin)
+	}
+	if filter.SalaryMax > 0 {
+		query = query.Where("jobs.salary_min <= ?", filter.SalaryMax)
+	}
+
+	return query
+}
+
+// SearchPublic runs a job board query. Relevance sorting needs a search
+// query and falls back to the most recently published jobs without one.
+func (r *JobRepository) SearchPublic(filter models.JobSearchFilter, limit, offset int) ([]*models.Job, int64, error) {
+	var jobs []*models.Job
+	var total int64
+
+	// Count on a joined query leaves its count(*) select behind, so the
+	// page is fetched with a fresh query
+	if err := r.searchPublic(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// The whole ORDER BY is one expression, gorm drops an expression when
+	// more columns are added with Order
+	order := clause.Expr{SQL: "jobs.published_at DESC, jobs.id DESC", WithoutParentheses: true}
+	switch {
+	case filter.Sort == models.JobSortSalary:
+		// Hidden salaries must not influence the order
+		order.SQL = "CASE WHEN jobs.show_salary THEN jobs.salary_max END DESC NULLS LAST, " + order.SQL
+	case filter.Sort == models.JobSortRelevance && filter.Query != "":
+		order.SQL = "ts_rank_cd(jobs.search_vector, websearch_to_tsquery('simple', ?)) DESC, " + order.SQL
+		order.Vars = []interface{}{filter.Query}
+	}
+
+	query := r.searchPublic(filter).Clauses(clause.OrderBy{Expression: order})
+	if err := query.Limit(limit).Offset(offset).Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// maxFacetValues caps the values returned for open ended facets like city
+const maxFacetValues = 20
+
+// Facets counts the jobs matching filter per facet value. A facet ignores
+// its own filter, so selecting Jakarta still shows the counts of other cities.
+func (r *JobRepository) Facets(filter models.JobSearchFilter) (*models.JobFacets, error) {
+	facets := &models.JobFacets{IsRemote: []models.FacetCount{}}
+	var err error
+
+	withoutJobType := filter
+	withoutJobType.JobTypes = nil
+	if facets.JobType, err = r.facetCounts(withoutJobType, "jobs.job_type"); err != nil {
+		return nil, err
+	}
+
+	withoutJobLevel := filter
+	withoutJobLevel.JobLevels = nil
+	if facets.JobLevel, err = r.facetCounts(withoutJobLevel, "jobs.job_level"); err != nil {
+		return nil, err
+	}
+
+	withoutCity := filter
+	withoutCity.City = ""
+	if facets.City, err = r.textFacetCounts(withoutCity, "jobs.city"); err != nil {
+		return nil, err
+	}
+
+	withoutCountry := filter
+	withoutCountry.Country = ""
+	if facets.Country, err = r.textFacetCounts(withoutCountry, "jobs.country"); err != nil {
+		return nil, err
+	}
+
+	withoutIndustry := filter
+	withoutIndustry.Industry = ""
+	if facets.Industry, err = r.textFacetCounts(withoutIndustry, "companies.industry"); err != nil {
+		return nil, err
+	}
+
+	withoutRemote := filter
+	withoutRemote.IsRemote = nil
+	if err := r.searchPublic(withoutRemote).
+		Select("CAST(jobs.is_remote AS TEXT) AS value, COUNT(*) AS count").
+		Where("jobs.is_remote IS NOT NULL").
+		Group("jobs.is_remote").
+		Order("count DESC").
+		Scan(&facets.IsRemote).Error; err != nil {
+		return nil, err
+	}
+
+	withoutSalary := filter
+	withoutSalary.SalaryMin, withoutSalary.SalaryMax = 0, 0
+	if facets.Salary, err = r.salaryBucketCounts(withoutSalary); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+func (r *JobRepository) facetCounts(filter models.JobSearchFilter, column string) ([]models.FacetCount, error) {
+	counts := []models.FacetCount{}
+	err := r.searchPublic(filter).
+		Select(column + " AS value, COUNT(*) AS count").
+		Where(column + " IS NOT NULL AND " + column + " <> ''").
+		Group(column).
+		Order("count DESC, value").
+		Limit(maxFacetValues).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// textFacetCounts groups free text values case-insensitively, the way their
+// filters match, so "Jakarta" and "jakarta" are one facet value
+func (r *JobRepository) textFacetCounts(filter models.JobSearchFilter, column string) ([]models.FacetCount, error) {
+	counts := []models.FacetCount{}
+	err := r.searchPublic(filter).
+		Select("MIN(" + column + ") AS value, COUNT(*) AS count").
+		Where(column + " IS NOT NULL AND " + column + " <> ''").
+		Group("LOWER(" + column + ")").
+		Order("count DESC, value").
+		Limit(maxFacetValues).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// salaryBucketCounts counts every bucket in one query. Like the salary
+// filter, a job is counted in each bucket its salary range overlaps.
+func (r *JobRepository) salaryBucketCounts(filter models.JobSearchFilter) ([]models.SalaryBucketCount, error) {
+	columns := make([]string, 0, len(models.SalaryBuckets))
+	var vars []interface{}
+	for _, bucket := range models.SalaryBuckets {
+		if bucket.Max == nil {
+			columns = append(columns, "COUNT(*) FILTER (WHERE jobs.show_salary AND jobs.salary_max >= ?)")
+			vars = append(vars, bucket.Min)
+			continue
+		}
+		columns = append(columns, "COUNT(*) FILTER (WHERE jobs.show_salary AND jobs.salary_max >= ? AND jobs.salary_min <= ?)")
+		vars = append(vars, bucket.Min, *bucket.Max)
+	}
+
+	row := r.searchPublic(filter).Select(strings.Join(columns, ", "), vars...).Row()
+
+	counts := make([]int64, len(models.SalaryBuckets))
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]models.SalaryBucketCount, len(models.SalaryBuckets))
+	for i, bucket := range models.SalaryBuckets {
+		buckets[i] = models.SalaryBucketCount{SalaryBucket: bucket, Count: counts[i]}
+	}
+	return buckets, nil
+}
+
+// GetPublicBySlug returns a job only while it is visible on the job board
+func (r *JobRepository) GetPublicBySlug(slug string) (*models.Job, error) {
+	var job models.Job
+	if err := r.publicJobs().Where("jobs.slug = ?", slug).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
 func (r *JobRepository) GetByCompanyID(companyID uint) ([]*models.Job, error) {
 	var jobs []*models.Job
 	if err := r.db.Where("company_id = ? AND deleted_at IS NULL", companyID).Order("created_at DESC").Find(&jobs).Error; err != nil {
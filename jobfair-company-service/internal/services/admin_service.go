@@ -0,0 +1,164 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+)
+
+// Audit log actions
+const (
+	AuditActionCompanyVerify        = "company.verify"
+	AuditActionCompanyUnverify      = "company.unverify"
+	AuditActionCompanyFeature       = "company.feature"
+	AuditActionCompanyUnfeature     = "company.unfeature"
+	AuditActionJobForceClose        = "job.force_close"
+	defaultCompanyVerificationBadge = "verified"
+)
+
+var (
+	ErrCompanyNotFound        = errors.New("company not found")
+	ErrJobNotFound            = errors.New("job not found")
+	ErrCompanyAlreadyVerified = errors.New("company is already verified")
+	ErrCompanyNotVerified     = errors.New("company is not verified")
+	ErrCompanyAlreadyFeatured = errors.New("company is already featured")
+	ErrCompanyNotFeatured     = errors.New("company is not featured")
+	ErrJobAlreadyForceClosed  = errors.New("job was already closed by an admin")
+)
+
+// AdminService backs the admin moderation API. Every state change is written
+// to admin_audit_logs together with the change itself.
+type AdminService struct {
+	adminRepo   *repository.AdminRepository
+	companyRepo *repository.CompanyRepository
+	jobRepo     *repository.JobRepository
+}
+
+func NewAdminService(adminRepo *repository.AdminRepository, companyRepo *repository.CompanyRepository, jobRepo *repository.JobRepository) *AdminService {
+	return &AdminService{
+		adminRepo:   adminRepo,
+		companyRepo: companyRepo,
+		jobRepo:     jobRepo,
+	}
+}
+
+// VerifyCompany marks a company as verified with the given badge
+func (s *AdminService) VerifyCompany(adminID, companyID uint, req *models.VerifyCompanyRequest, ipAddress string) (*models.Company, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+	if company.IsVerified {
+		return nil, ErrCompanyAlreadyVerified
+	}
+
+	badge := req.VerificationBadge
+	if badge == "" {
+		badge = defaultCompanyVerificationBadge
+	}
+
+	entry := auditEntry(adminID, AuditActionCompanyVerify, "company", companyID, req.Reason, ipAddress,
+		map[string]interface{}{"is_verified": false},
+		map[string]interface{}{"is_verified": true, "verification_badge": badge},
+	)
+	if err := s.adminRepo.SetCompanyVerified(companyID, true, badge, entry); err != nil {
+		return nil, err
+	}
+
+	return s.companyRepo.GetByID(companyID)
+}
+
+func (s *AdminService) UnverifyCompany(adminID, companyID uint, reason, ipAddress string) (*models.Company, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+	if !company.IsVerified {
+		return nil, ErrCompanyNotVerified
+	}
+
+	entry := auditEntry(adminID, AuditActionCompanyUnverify, "company", companyID, reason, ipAddress,
+		map[string]interface{}{"is_verified": true, "verification_badge": company.VerificationBadge},
+		map[string]interface{}{"is_verified": false},
+	)
+	if err := s.adminRepo.SetCompanyVerified(companyID, false, "", entry); err != nil {
+		return nil, err
+	}
+
+	return s.companyRepo.GetByID(companyID)
+}
+
+// SetCompanyFeatured features or unfeatures a company
+func (s *AdminService) SetCompanyFeatured(adminID, companyID uint, featured bool, reason, ipAddress string) (*models.Company, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	action := AuditActionCompanyFeature
+	if !featured {
+		action = AuditActionCompanyUnfeature
+	}
+	switch {
+	case featured && company.IsFeatured:
+		return nil, ErrCompanyAlreadyFeatured
+	case !featured && !company.IsFeatured:
+		return nil, ErrCompanyNotFeatured
+	}
+
+	entry := auditEntry(adminID, action, "company", companyID, reason, ipAddress,
+		map[string]interface{}{"is_featured": company.IsFeatured},
+		map[string]interface{}{"is_featured": featured},
+	)
+	if err := s.adminRepo.SetCompanyFeatured(companyID, featured, entry); err != nil {
+		return nil, err
+	}
+
+	return s.companyRepo.GetByID(companyID)
+}
+
+// ForceCloseJob closes a job regardless of its owner. The company cannot
+// publish it again afterwards.
+func (s *AdminService) ForceCloseJob(adminID, jobID uint, reason, ipAddress string) (*models.Job, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+	if job.ModeratedAt != nil {
+		return nil, ErrJobAlreadyForceClosed
+	}
+
+	entry := auditEntry(adminID, AuditActionJobForceClose, "job", jobID, reason, ipAddress,
+		map[string]interface{}{"status": job.Status},
+		map[string]interface{}{"status": models.JobStatusClosed},
+	)
+	if err := s.adminRepo.ForceCloseJob(jobID, reason, entry); err != nil {
+		return nil, err
+	}
+
+	return s.jobRepo.GetByID(jobID)
+}
+
+func (s *AdminService) ListAuditLogs(filter models.AuditLogFilter, limit, offset int) ([]*models.AdminAuditLog, int64, error) {
+	return s.adminRepo.ListAuditLogs(filter, limit, offset)
+}
+
+func auditEntry(adminID uint, action, targetType string, targetID uint, reason, ipAddress string, before, after map[string]interface{}) *models.AdminAuditLog {
+	entry := &models.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		IPAddress:  ipAddress,
+	}
+
+	if details, err := json.Marshal(map[string]interface{}{"before": before, "after": after}); err == nil {
+		value := string(details)
+		entry.Details = &value
+	}
+
+	return entry
+}
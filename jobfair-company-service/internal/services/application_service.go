@@ -2,27 +2,202 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"time"
 
 	"jobfair-company-service/internal/models"
 	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/utils"
+)
+
+var (
+	ErrAlreadyApplied   = errors.New("you have already applied to this job")
+	ErrNoStoredResume   = errors.New("application was not submitted with a stored resume")
+	ErrInvalidResumeURL = errors.New("resume_url must be an https URL")
 )
 
 type ApplicationService struct {
 	applicationRepo *repository.ApplicationRepository
 	jobRepo         *repository.JobRepository
+	// resumes is nil when INTERNAL_API_SECRET is not set, applications then
+	// need a resume_url
+	resumes *ResumeClient
 }
 
-func NewApplicationService(applicationRepo *repository.ApplicationRepository, jobRepo *repository.JobRepository) *ApplicationService {
+func NewApplicationService(applicationRepo *repository.ApplicationRepository, jobRepo *repository.JobRepository, resumes *ResumeClient) *ApplicationService {
 	return &ApplicationService{
 		applicationRepo: applicationRepo,
 		jobRepo:         jobRepo,
+		resumes:         resumes,
+	}
+}
+
+func (s *ApplicationService) ApplyToJob(jobID uint, userID uint, req *models.ApplyJobRequest, resume *multipart.FileHeader) (*models.JobApplication, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, errors.New("job not found")
+	}
+
+	if job.Status != models.JobStatusActive {
+		return nil, errors.New("job is not accepting applications")
+	}
+
+	if job.ExpiresAt != nil && time.Now().After(*job.ExpiresAt) {
+		return nil, errors.New("job posting has expired")
+	}
+
+	if existing, _ := s.applicationRepo.GetByJobAndUser(jobID, userID); existing != nil {
+		return nil, ErrAlreadyApplied
+	}
+
+	resumeURL := req.ResumeURL
+	if resumeURL != "" && !validResumeURL(resumeURL) {
+		return nil, ErrInvalidResumeURL
+	}
+
+	var resumeID *uint
+	var resumeData *models.ParsedResume
+	uploaded := false
+	switch {
+	case resume != nil:
+		if err := utils.ValidateFile(resume, utils.DocumentConfig); err != nil {
+			return nil, err
+		}
+		// Uploaded files are stored and parsed by the resume library too,
+		// outside the applicant's library
+		if s.resumes == nil {
+			return nil, ErrResumeLibraryOffline
+		}
+		stored, err := s.resumes.Upload(userID, resume)
+		if err != nil {
+			return nil, err
+		}
+		resumeID = &stored.ID
+		resumeURL = ""
+		resumeData = stored.Parsed
+		uploaded = true
+	case req.ResumeID != 0 || resumeURL == "":
+		// A resume from the library, the default one unless resume_id is set
+		if s.resumes == nil {
+			if req.ResumeID != 0 {
+				return nil, ErrResumeLibraryOffline
+			}
+			return nil, errors.New("resume is required")
+		}
+		stored, err := s.resumes.Resolve(userID, req.ResumeID)
+		if errors.Is(err, ErrResumeNotFound) && req.ResumeID == 0 {
+			return nil, errors.New("resume is required, upload one to your resume library first")
+		}
+		if err != nil {
+			return nil, err
+		}
+		resumeID = &stored.ID
+		resumeURL = ""
+		resumeData = stored.Parsed
+	}
+
+	application := &models.JobApplication{
+		JobID:       job.ID,
+		UserID:      userID,
+		CompanyID:   job.CompanyID,
+		Status:      models.ApplicationStatusApplied,
+		CoverLetter: req.CoverLetter,
+		ResumeURL:   resumeURL,
+		ResumeID:    resumeID,
+		AppliedAt:   time.Now(),
+	}
+	if resumeData != nil {
+		application.ResumeData = resumeData
+		for _, skill := range resumeData.Skills {
+			application.ResumeSkills = append(application.ResumeSkills, strings.ToLower(skill))
+		}
+	}
+
+	created, err := s.applicationRepo.CreateForJob(application)
+	if err != nil {
+		if uploaded {
+			if err := s.resumes.Discard(userID, *resumeID); err != nil {
+				log.Printf("Warning: failed to discard resume %d of a failed application: %v", *resumeID, err)
+			}
+		}
+		// Two concurrent submissions can both pass the check above
+		if strings.Contains(err.Error(), "job_applications_user_job_unique") {
+			return nil, ErrAlreadyApplied
+		}
+		return nil, err
 	}
+
+	return created, nil
 }
 
 func (s *ApplicationService) GetApplication(id uint) (*models.JobApplication, error) {
 	return s.applicationRepo.GetByID(id)
 }
 
+// GetApplicationDetail is the recruiter's view of an application, applicant
+// details come from the parsed resume
+func (s *ApplicationService) GetApplicationDetail(application *models.JobApplication) (*models.JobApplicationDetail, error) {
+	job, err := s.jobRepo.GetByID(application.JobID)
+	if err != nil {
+		return nil, errors.New("job not found")
+	}
+
+	detail := &models.JobApplicationDetail{
+		ID:          application.ID,
+		JobID:       application.JobID,
+		JobTitle:    job.Title,
+		Location:    job.Location,
+		DateApplied: application.AppliedAt,
+		Status:      application.Status,
+		ResumeURL:   application.ResumeURL,
+		ResumeID:    application.ResumeID,
+		CoverLetter: application.CoverLetter,
+		Skills:      []string{},
+		Education:   []models.ResumeEducation{},
+		WorkHistory: []models.ResumeExperience{},
+	}
+
+	if parsed := application.ResumeData; parsed != nil {
+		detail.ApplicantName = parsed.Contact.Name
+		detail.ApplicantEmail = parsed.Contact.Email
+		detail.ApplicantPhone = parsed.Contact.Phone
+		detail.Experience = formatExperience(parsed.TotalExperienceMonths)
+		if parsed.Skills != nil {
+			detail.Skills = parsed.Skills
+		}
+		if parsed.Education != nil {
+			detail.Education = parsed.Education
+		}
+		if parsed.Experience != nil {
+			detail.WorkHistory = parsed.Experience
+			// Positions are listed newest first in almost every resume
+			if len(parsed.Experience) > 0 {
+				detail.Position = parsed.Experience[0].Title
+			}
+		}
+	}
+
+	return detail, nil
+}
+
+// ResumeDownloadLink returns a time-limited link to the library resume an
+// application was submitted with. The caller checks that the application
+// belongs to the company.
+func (s *ApplicationService) ResumeDownloadLink(application *models.JobApplication) (*models.ResumeDownloadLink, error) {
+	if application.ResumeID == nil {
+		return nil, ErrNoStoredResume
+	}
+	if s.resumes == nil {
+		return nil, ErrResumeLibraryOffline
+	}
+
+	return s.resumes.DownloadLink(application.UserID, *application.ResumeID)
+}
+
 func (s *ApplicationService) ListApplications(companyID uint, limit, offset int, filters map[string]interface{}) ([]*models.JobApplication, int64, error) {
 	return s.applicationRepo.List(companyID, limit, offset, filters)
 }
@@ -40,7 +215,7 @@ func (s *ApplicationService) GetApplicationsByJobID(jobID uint, companyID uint)
 	return s.applicationRepo.GetByJobID(jobID)
 }
 
-func (s *ApplicationService) UpdateApplicationStatus(id uint, companyID uint, status models.ApplicationStatus) error {
+func (s *ApplicationService) UpdateApplicationStatus(id uint, companyID uint, changedBy uint, req *models.UpdateApplicationStatusRequest) error {
 	application, err := s.applicationRepo.GetByID(id)
 	if err != nil {
 		return err
@@ -50,7 +225,78 @@ func (s *ApplicationService) UpdateApplicationStatus(id uint, companyID uint, st
 		return errors.New("unauthorized to update this application")
 	}
 
-	return s.applicationRepo.UpdateStatus(id, status)
+	if req.Status == models.ApplicationStatusWithdrawn {
+		return errors.New("only the applicant can withdraw an application")
+	}
+
+	if !application.Status.CanTransitionTo(req.Status) {
+		return fmt.Errorf("cannot change application status from %s to %s", application.Status, req.Status)
+	}
+
+	from := application.Status
+	return s.applicationRepo.UpdateStatus(id, req.Status, &models.ApplicationStatusHistory{
+		FromStatus:    &from,
+		ChangedBy:     changedBy,
+		ChangedByType: "company",
+		Note:          req.Note,
+	})
+}
+
+func (s *ApplicationService) GetStatusHistory(id uint, companyID uint) ([]*models.ApplicationStatusHistory, error) {
+	application, err := s.applicationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.CompanyID != companyID {
+		return nil, errors.New("unauthorized to view this application")
+	}
+
+	return s.applicationRepo.GetStatusHistory(id)
+}
+
+func (s *ApplicationService) ListMyApplications(userID uint, limit, offset int, filters map[string]interface{}) ([]*models.MyApplicationResponse, int64, error) {
+	return s.applicationRepo.ListByUserID(userID, limit, offset, filters)
+}
+
+func (s *ApplicationService) GetMyApplication(id uint, userID uint) (*models.MyApplicationResponse, error) {
+	return s.applicationRepo.GetByIDForUser(id, userID)
+}
+
+func (s *ApplicationService) WithdrawApplication(id uint, userID uint, note string) error {
+	application, err := s.applicationRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if application.UserID != userID {
+		return errors.New("unauthorized to withdraw this application")
+	}
+
+	if !application.Status.CanTransitionTo(models.ApplicationStatusWithdrawn) {
+		return fmt.Errorf("application is %s and can no longer be withdrawn", application.Status)
+	}
+
+	from := application.Status
+	return s.applicationRepo.UpdateStatus(id, models.ApplicationStatusWithdrawn, &models.ApplicationStatusHistory{
+		FromStatus:    &from,
+		ChangedBy:     userID,
+		ChangedByType: "job_seeker",
+		Note:          note,
+	})
+}
+
+func (s *ApplicationService) GetMyStatusHistory(id uint, userID uint) ([]*models.ApplicationStatusHistory, error) {
+	application, err := s.applicationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.UserID != userID {
+		return nil, errors.New("unauthorized to view this application")
+	}
+
+	return s.applicationRepo.GetStatusHistory(id)
 }
 
 func (s *ApplicationService) GetApplicationStats(companyID uint) (map[string]interface{}, error) {
@@ -59,6 +305,7 @@ func (s *ApplicationService) GetApplicationStats(companyID uint) (map[string]int
 	hired, _ := s.applicationRepo.CountByStatus(companyID, models.ApplicationStatusHired)
 	interview, _ := s.applicationRepo.CountByStatus(companyID, models.ApplicationStatusInterview)
 	rejected, _ := s.applicationRepo.CountByStatus(companyID, models.ApplicationStatusRejected)
+	withdrawn, _ := s.applicationRepo.CountByStatus(companyID, models.ApplicationStatusWithdrawn)
 
 	stats := map[string]interface{}{
 		"total":       total,
@@ -66,7 +313,38 @@ func (s *ApplicationService) GetApplicationStats(companyID uint) (map[string]int
 		"hired":       hired,
 		"interview":   interview,
 		"rejected":    rejected,
+		"withdrawn":   withdrawn,
 	}
 
 	return stats, nil
+}
+
+// validResumeURL only accepts absolute https links, the URL is shown to
+// recruiters as is
+func validResumeURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != "" && u.User == nil
+}
+
+// formatExperience renders a number of months as "3 years 2 months"
+func formatExperience(months int) string {
+	if months <= 0 {
+		return ""
+	}
+
+	var parts []string
+	if years := months / 12; years > 0 {
+		parts = append(parts, plural(years, "year"))
+	}
+	if rest := months % 12; rest > 0 {
+		parts = append(parts, plural(rest, "month"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
 }
\ No newline at end of file
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
@@ -10,26 +13,35 @@ import (
 
 	"jobfair-company-service/internal/models"
 	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/utils"
+	"jobfair-shared-libs/imaging"
+	"jobfair-shared-libs/storage"
 	"github.com/gosimple/slug"
 )
 
 type CompanyService struct {
 	companyRepo     *repository.CompanyRepository
+	memberRepo      *repository.CompanyMemberRepository
 	jobRepo         *repository.JobRepository
 	applicationRepo *repository.ApplicationRepository
+	store           storage.Storage
 }
 
-func NewCompanyService(companyRepo *repository.CompanyRepository, jobRepo *repository.JobRepository, applicationRepo *repository.ApplicationRepository) *CompanyService {
+func NewCompanyService(companyRepo *repository.CompanyRepository, memberRepo *repository.CompanyMemberRepository, jobRepo *repository.JobRepository, applicationRepo *repository.ApplicationRepository, store storage.Storage) *CompanyService {
 	return &CompanyService{
 		companyRepo:     companyRepo,
+		memberRepo:      memberRepo,
 		jobRepo:         jobRepo,
 		applicationRepo: applicationRepo,
+		store:           store,
 	}
 }
 
+// CreateCompany creates a company with the caller as its owner. A user can
+// only belong to one company, either as its creator or through an invitation.
 func (s *CompanyService) CreateCompany(userID uint, req *models.CreateCompanyRequest) (*models.Company, error) {
-	if existing, _ := s.companyRepo.GetByUserID(userID); existing != nil {
-		return nil, errors.New("company already exists for this user")
+	if existing, _ := s.memberRepo.GetByUserID(userID); existing != nil {
+		return nil, errors.New("you already belong to a company")
 	}
 
 	company := &models.Company{
@@ -49,7 +61,7 @@ func (s *CompanyService) CreateCompany(userID uint, req *models.CreateCompanyReq
 		IsVerified:  false,
 	}
 
-	createdCompany, err := s.companyRepo.Create(company)
+	createdCompany, err := s.companyRepo.CreateWithOwner(company)
 	if err != nil {
 		return nil, err
 	}
@@ -75,8 +87,10 @@ func (s *CompanyService) GetCompany(id uint) (*models.Company, error) {
 	return company, nil
 }
 
-func (s *CompanyService) GetCompanyByUserID(userID uint) (*models.Company, error) {
-	return s.companyRepo.GetByUserID(userID)
+// GetCompanyByID returns a company without counting a profile view, for
+// members looking at their own company
+func (s *CompanyService) GetCompanyByID(id uint) (*models.Company, error) {
+	return s.companyRepo.GetByID(id)
 }
 
 func (s *CompanyService) UpdateCompany(id uint, req *models.UpdateCompanyRequest) (*models.Company, error) {
@@ -145,65 +159,117 @@ func (s *CompanyService) UpdateCompany(id uint, req *models.UpdateCompanyRequest
 	return company, nil
 }
 
-func (s *CompanyService) UploadFile(companyID uint, file *multipart.FileHeader, fileType string) (string, error) {
+// UploadFile replaces the company logo or banner. Gallery images and videos
+// are managed by MediaService.
+func (s *CompanyService) UploadFile(companyID uint, file *multipart.FileHeader, fileType string) (*models.UploadedFile, error) {
+	if fileType != "logo" && fileType != "banner" {
+		return nil, errors.New("invalid file type")
+	}
+
 	company, err := s.companyRepo.GetByID(companyID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err := s.validateFile(file, fileType); err != nil {
-		return "", err
+	if err := utils.ValidateFile(file, utils.ImageConfig); err != nil {
+		return nil, err
 	}
 
-	filename := fmt.Sprintf("%d_%s_%d%s", companyID, fileType, time.Now().Unix(), filepath.Ext(file.Filename))
-	url := fmt.Sprintf("/uploads/%s", filename)
+	// Keys are content addressed, uploading the same file again reuses it
+	uploaded, err := storeImage(s.store, fmt.Sprintf("companies/%d/%s", companyID, fileType), file)
+	if err != nil {
+		return nil, err
+	}
+	url := uploaded.URL
 
+	var replaced string
 	switch fileType {
 	case "logo":
+		replaced = company.LogoURL
 		company.LogoURL = url
 	case "banner":
+		replaced = company.BannerURL
 		company.BannerURL = url
-	case "video":
-		company.VideoURLs = append(company.VideoURLs, url)
-	case "gallery":
-		company.GalleryURLs = append(company.GalleryURLs, url)
 	}
 
 	if err := s.companyRepo.Update(company); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if replaced != "" && replaced != url {
+		deleteStoredFile(s.store, replaced)
 	}
 
-	return url, nil
+	return uploaded, nil
 }
 
-func (s *CompanyService) validateFile(file *multipart.FileHeader, fileType string) error {
-	maxSize := int64(10 * 1024 * 1024)
-	if fileType == "video" {
-		maxSize = int64(50 * 1024 * 1024)
+// storeImage strips the metadata of an uploaded image and stores it in every
+// variant size
+func storeImage(store storage.Storage, prefix string, file *multipart.FileHeader) (*models.UploadedFile, error) {
+	data, err := readUpload(file)
+	if err != nil {
+		return nil, err
 	}
 
-	if file.Size > maxSize {
-		return fmt.Errorf("file size too large (max %dMB)", maxSize/(1024*1024))
+	if _, err := imaging.CheckFormat(data, strings.ToLower(filepath.Ext(file.Filename))); err != nil {
+		return nil, err
 	}
 
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	var allowed []string
+	keys, err := imaging.Store(context.Background(), store, prefix, data)
+	if err != nil {
+		return nil, err
+	}
 
-	switch fileType {
-	case "logo", "banner", "gallery":
-		allowed = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
-	case "video":
-		allowed = []string{".mp4", ".avi", ".mov", ".webm"}
-	default:
-		return errors.New("invalid file type")
+	variants := imaging.VariantURLs(keys)
+	return &models.UploadedFile{URL: variants[imaging.VariantOriginal], Variants: variants}, nil
+}
+
+// storeFile stores an upload as it is, streaming it to storage
+func storeFile(store storage.Storage, prefix string, file *multipart.FileHeader) (*models.UploadedFile, error) {
+	detected, err := utils.DetectMIMEType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	key, err := storage.PutContentAddressed(context.Background(), store, prefix, filepath.Ext(file.Filename), src, detected.String())
+	if err != nil {
+		return nil, err
 	}
 
-	for _, a := range allowed {
-		if ext == a {
-			return nil
+	return &models.UploadedFile{URL: storage.URL(key)}, nil
+}
+
+// deleteStoredFile removes a file this service stored, together with its
+// image variants. A failure only leaves an orphaned object behind, so it is
+// logged rather than returned.
+func deleteStoredFile(store storage.Storage, url string) {
+	key, ok := storage.KeyFromURL(url)
+	if !ok {
+		return
+	}
+	for _, variantKey := range imaging.VariantKeys(key) {
+		if err := store.Delete(context.Background(), variantKey); err != nil {
+			log.Printf("Warning: failed to delete replaced file %s: %v", variantKey, err)
 		}
 	}
-	return errors.New("invalid file format")
+}
+
+// readUpload loads an upload into memory, only used for images which are
+// bounded by utils.ImageConfig.MaxFileSize
+func readUpload(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return io.ReadAll(src)
 }
 
 func (s *CompanyService) GetAnalytics(companyID uint) (*models.CompanyAnalytics, error) {
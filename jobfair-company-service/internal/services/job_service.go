@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"jobfair-company-service/internal/models"
@@ -9,6 +10,10 @@ import (
 	"github.com/gosimple/slug"
 )
 
+// ErrJobModerated is returned when a company tries to reopen a job that an
+// admin has force-closed
+var ErrJobModerated = errors.New("job was closed by an admin and cannot be reopened")
+
 type JobService struct {
 	jobRepo         *repository.JobRepository
 	companyRepo     *repository.CompanyRepository
@@ -49,7 +54,11 @@ func (s *JobService) CreateJob(companyID uint, req *models.CreateJobRequest) (*m
 		Benefits:         req.Benefits,
 		Positions:        req.Positions,
 		ExpiresAt:        req.ExpiresAt,
-		Slug:             slug.Make(req.Title + "-" + company.Name),
+	}
+
+	job.Slug, err = s.uniqueSlug(slug.Make(req.Title+"-"+company.Name), 0)
+	if err != nil {
+		return nil, err
 	}
 
 	return s.jobRepo.Create(job)
@@ -80,7 +89,10 @@ func (s *JobService) UpdateJob(id uint, companyID uint, req *models.UpdateJobReq
 		job.Title = *req.Title
 		company, _ := s.companyRepo.GetByID(companyID)
 		if company != nil {
-			job.Slug = slug.Make(*req.Title + "-" + company.Name)
+			job.Slug, err = s.uniqueSlug(slug.Make(*req.Title+"-"+company.Name), job.ID)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 	if req.Description != nil {
@@ -99,6 +111,9 @@ func (s *JobService) UpdateJob(id uint, companyID uint, req *models.UpdateJobReq
 		job.JobLevel = *req.JobLevel
 	}
 	if req.Status != nil {
+		if job.ModeratedAt != nil && *req.Status != models.JobStatusClosed {
+			return nil, ErrJobModerated
+		}
 		job.Status = *req.Status
 		if *req.Status == models.JobStatusActive && job.PublishedAt == nil {
 			now := time.Now()
@@ -163,6 +178,87 @@ func (s *JobService) ListJobs(companyID uint, limit, offset int, filters map[str
 	return s.jobRepo.List(companyID, limit, offset, filters)
 }
 
+// SearchPublicJobs runs a public job board query across all companies
+func (s *JobService) SearchPublicJobs(filter models.JobSearchFilter, limit, offset int) ([]models.PublicJob, int64, error) {
+	jobs, total, err := s.jobRepo.SearchPublic(filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	companies, err := s.companiesOf(jobs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]models.PublicJob, 0, len(jobs))
+	for _, job := range jobs {
+		results = append(results, models.NewPublicJob(job, companies[job.CompanyID], false))
+	}
+
+	return results, total, nil
+}
+
+// JobFacets returns the facet counts of a job board query
+func (s *JobService) JobFacets(filter models.JobSearchFilter) (*models.JobFacets, error) {
+	return s.jobRepo.Facets(filter)
+}
+
+// GetPublicJob returns a job board posting by slug and counts the view
+func (s *JobService) GetPublicJob(jobSlug string) (*models.PublicJob, error) {
+	job, err := s.jobRepo.GetPublicBySlug(jobSlug)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+
+	company, err := s.companyRepo.GetByID(job.CompanyID)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+
+	s.jobRepo.IncrementViewCount(job.ID)
+
+	public := models.NewPublicJob(job, company, true)
+	return &public, nil
+}
+
+func (s *JobService) companiesOf(jobs []*models.Job) (map[uint]*models.Company, error) {
+	ids := make([]uint, 0, len(jobs))
+	seen := make(map[uint]bool)
+	for _, job := range jobs {
+		if !seen[job.CompanyID] {
+			seen[job.CompanyID] = true
+			ids = append(ids, job.CompanyID)
+		}
+	}
+
+	companies, err := s.companyRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*models.Company, len(companies))
+	for _, company := range companies {
+		byID[company.ID] = company
+	}
+	return byID, nil
+}
+
+// uniqueSlug appends -2, -3, ... until no other job uses the slug, the
+// public job page is looked up by it
+func (s *JobService) uniqueSlug(base string, jobID uint) (string, error) {
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := s.jobRepo.SlugExists(candidate, jobID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
 func (s *JobService) PublishJob(id uint, companyID uint) error {
 	job, err := s.jobRepo.GetByID(id)
 	if err != nil {
@@ -173,6 +269,10 @@ func (s *JobService) PublishJob(id uint, companyID uint) error {
 		return errors.New("unauthorized to publish this job")
 	}
 
+	if job.ModeratedAt != nil {
+		return ErrJobModerated
+	}
+
 	return s.jobRepo.UpdateStatus(id, models.JobStatusActive)
 }
 
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/utils"
+	"jobfair-shared-libs/imaging"
+	"jobfair-shared-libs/storage"
+)
+
+var (
+	ErrMediaNotFound     = errors.New("media not found")
+	ErrMediaLimitReached = repository.ErrMediaLimitReached
+	ErrInvalidMediaOrder = repository.ErrInvalidMediaOrder
+)
+
+// MediaService manages the gallery images and videos of a company's virtual
+// booth. How many items a company may keep depends on its subscription tier.
+type MediaService struct {
+	mediaRepo   *repository.CompanyMediaRepository
+	companyRepo *repository.CompanyRepository
+	store       storage.Storage
+}
+
+func NewMediaService(mediaRepo *repository.CompanyMediaRepository, companyRepo *repository.CompanyRepository, store storage.Storage) *MediaService {
+	return &MediaService{
+		mediaRepo:   mediaRepo,
+		companyRepo: companyRepo,
+		store:       store,
+	}
+}
+
+// ListMedia returns the company's gallery and videos in display order
+func (s *MediaService) ListMedia(companyID uint) (*models.CompanyMediaList, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	items, err := s.mediaRepo.ListByCompany(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.CompanyMediaList{
+		Gallery: []*models.CompanyMedia{},
+		Videos:  []*models.CompanyMedia{},
+		Limits: models.MediaLimit{
+			Gallery: company.SubscriptionTier.MediaLimit(models.MediaTypeGallery),
+			Video:   company.SubscriptionTier.MediaLimit(models.MediaTypeVideo),
+		},
+	}
+	for _, item := range items {
+		if item.MediaType == models.MediaTypeVideo {
+			list.Videos = append(list.Videos, item)
+		} else {
+			list.Gallery = append(list.Gallery, item)
+		}
+	}
+
+	return list, nil
+}
+
+// UploadMedia stores a gallery image or video and appends it to the
+// company's items of that type
+func (s *MediaService) UploadMedia(companyID uint, mediaType models.MediaType, file *multipart.FileHeader, req *models.UploadMediaRequest) (*models.CompanyMedia, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	config := utils.ImageConfig
+	if mediaType == models.MediaTypeVideo {
+		config = utils.VideoConfig
+	}
+	if err := utils.ValidateFile(file, config); err != nil {
+		return nil, err
+	}
+
+	detected, err := utils.DetectMIMEType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("companies/%d/%s", companyID, mediaType)
+	var uploaded *models.UploadedFile
+	if mediaType == models.MediaTypeVideo {
+		uploaded, err = storeFile(s.store, prefix, file)
+	} else {
+		uploaded, err = storeImage(s.store, prefix, file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	media := &models.CompanyMedia{
+		CompanyID:    companyID,
+		MediaType:    mediaType,
+		FileName:     filepath.Base(file.Filename),
+		FileURL:      uploaded.URL,
+		FileSize:     file.Size,
+		MimeType:     detected.String(),
+		ThumbnailURL: uploaded.Variants[imaging.VariantThumbnail],
+		Title:        req.Title,
+		Description:  req.Description,
+		AltText:      req.AltText,
+		Variants:     uploaded.Variants,
+	}
+
+	if err := s.mediaRepo.CreateWithinLimit(media, company.SubscriptionTier.MediaLimit(mediaType)); err != nil {
+		s.deleteUnusedFile(companyID, uploaded.URL)
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// UpdateMedia changes the caption of an item, fields left nil are kept
+func (s *MediaService) UpdateMedia(companyID, mediaID uint, req *models.UpdateMediaRequest) (*models.CompanyMedia, error) {
+	media, err := s.mediaRepo.GetByID(companyID, mediaID)
+	if err != nil {
+		return nil, ErrMediaNotFound
+	}
+
+	if req.Title != nil {
+		media.Title = *req.Title
+	}
+	if req.Description != nil {
+		media.Description = *req.Description
+	}
+	if req.AltText != nil {
+		media.AltText = *req.AltText
+	}
+
+	if err := s.mediaRepo.UpdateCaption(media); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// DeleteMedia removes an item and, unless another item shares it, its file
+func (s *MediaService) DeleteMedia(companyID, mediaID uint) error {
+	media, err := s.mediaRepo.GetByID(companyID, mediaID)
+	if err != nil {
+		return ErrMediaNotFound
+	}
+
+	if err := s.mediaRepo.Delete(media); err != nil {
+		return err
+	}
+
+	s.deleteUnusedFile(companyID, media.FileURL)
+	return nil
+}
+
+// ReorderMedia sets the display order of every item of one type
+func (s *MediaService) ReorderMedia(companyID uint, req *models.ReorderMediaRequest) (*models.CompanyMediaList, error) {
+	if err := s.mediaRepo.Reorder(companyID, req.MediaType, req.MediaIDs); err != nil {
+		return nil, err
+	}
+
+	return s.ListMedia(companyID)
+}
+
+// deleteUnusedFile removes a stored file once no item of the company points
+// at it anymore, uploading the same bytes twice yields the same URL
+func (s *MediaService) deleteUnusedFile(companyID uint, fileURL string) {
+	count, err := s.mediaRepo.CountByURL(companyID, fileURL)
+	if err != nil {
+		log.Printf("Warning: failed to check usage of %s: %v", fileURL, err)
+		return
+	}
+	if count == 0 {
+		deleteStoredFile(s.store, fileURL)
+	}
+}
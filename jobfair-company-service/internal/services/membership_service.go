@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/utils"
+	"jobfair-shared-libs/notification"
+
+	"gorm.io/gorm"
+)
+
+// invitationMailTimeout bounds sending the invitation email, so a stuck SMTP
+// server cannot hold the request
+const invitationMailTimeout = 20 * time.Second
+
+var (
+	ErrNotCompanyMember       = errors.New("you are not a member of any company")
+	ErrInsufficientMemberRole = errors.New("your company role does not allow this action")
+	ErrAlreadyCompanyMember   = errors.New("user already belongs to a company")
+	ErrInvalidInvitation      = errors.New("invalid or expired invitation")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrMemberNotFound         = errors.New("member not found")
+	ErrCannotModifyOwner      = errors.New("the company owner cannot be changed or removed")
+)
+
+// InvitationSettings controls invitation links
+type InvitationSettings struct {
+	// URL is the page the emailed link points to, the token is appended as
+	// the "token" query parameter
+	URL string
+	TTL time.Duration
+}
+
+// MembershipService resolves which company a user acts for and with which
+// role, and manages members and invitations of a company.
+type MembershipService struct {
+	memberRepo     *repository.CompanyMemberRepository
+	invitationRepo *repository.CompanyInvitationRepository
+	companyRepo    *repository.CompanyRepository
+	mailer         notification.Mailer
+	settings       InvitationSettings
+}
+
+func NewMembershipService(
+	memberRepo *repository.CompanyMemberRepository,
+	invitationRepo *repository.CompanyInvitationRepository,
+	companyRepo *repository.CompanyRepository,
+	mailer notification.Mailer,
+	settings InvitationSettings,
+) *MembershipService {
+	return &MembershipService{
+		memberRepo:     memberRepo,
+		invitationRepo: invitationRepo,
+		companyRepo:    companyRepo,
+		mailer:         mailer,
+		settings:       settings,
+	}
+}
+
+// Authorize returns the caller's membership if their role grants permission
+func (s *MembershipService) Authorize(userID uint, permission models.MemberPermission) (*models.CompanyMember, error) {
+	member, err := s.memberRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotCompanyMember
+		}
+		return nil, err
+	}
+
+	if !member.Role.Can(permission) {
+		return nil, ErrInsufficientMemberRole
+	}
+
+	return member, nil
+}
+
+func (s *MembershipService) ListMembers(companyID uint) ([]*models.CompanyMember, error) {
+	return s.memberRepo.ListByCompany(companyID)
+}
+
+// InviteMember emails a single-use invitation link. Inviting the same email
+// again replaces the previous link.
+func (s *MembershipService) InviteMember(actor *models.CompanyMember, req *models.InviteMemberRequest) (*models.CompanyInvitation, error) {
+	if req.Role == models.MemberRoleAdmin && actor.Role != models.MemberRoleOwner {
+		return nil, ErrInsufficientMemberRole
+	}
+
+	company, err := s.companyRepo.GetByID(actor.CompanyID)
+	if err != nil {
+		return nil, errors.New("company not found")
+	}
+
+	token, tokenHash, err := utils.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &models.CompanyInvitation{
+		CompanyID: actor.CompanyID,
+		Email:     strings.TrimSpace(req.Email),
+		Role:      req.Role,
+		TokenHash: tokenHash,
+		InvitedBy: actor.UserID,
+		ExpiresAt: time.Now().Add(s.settings.TTL),
+	}
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	subject := fmt.Sprintf("Undangan bergabung dengan %s di JobFair", company.Name)
+	ctx, cancel := context.WithTimeout(context.Background(), invitationMailTimeout)
+	defer cancel()
+	if err := s.mailer.SendMail(ctx, invitation.Email, subject, s.invitationMessage(company, invitation, token)); err != nil {
+		return nil, fmt.Errorf("invitation created but the email could not be sent: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (s *MembershipService) ListInvitations(companyID uint) ([]*models.CompanyInvitation, error) {
+	return s.invitationRepo.ListPending(companyID)
+}
+
+func (s *MembershipService) RevokeInvitation(companyID, invitationID uint) error {
+	revoked, err := s.invitationRepo.Revoke(companyID, invitationID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation adds the caller to the inviting company. Holding the token
+// proves access to the invited mailbox, so any company account may accept it.
+func (s *MembershipService) AcceptInvitation(userID uint, token string) (*models.CompanyMember, error) {
+	invitation, err := s.invitationRepo.GetByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+
+	if existing, _ := s.memberRepo.GetByUserID(userID); existing != nil {
+		return nil, ErrAlreadyCompanyMember
+	}
+
+	invitedBy := invitation.InvitedBy
+	member := &models.CompanyMember{
+		CompanyID: invitation.CompanyID,
+		UserID:    userID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		InvitedBy: &invitedBy,
+	}
+
+	if err := s.invitationRepo.Accept(invitation, member); err != nil {
+		if errors.Is(err, repository.ErrInvitationUnavailable) {
+			return nil, ErrInvalidInvitation
+		}
+		// Joined another company concurrently
+		if strings.Contains(err.Error(), "company_members_user_id_unique") {
+			return nil, ErrAlreadyCompanyMember
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *MembershipService) UpdateMemberRole(actor *models.CompanyMember, memberID uint, role models.MemberRole) (*models.CompanyMember, error) {
+	member, err := s.manageableMember(actor, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.MemberRoleAdmin && actor.Role != models.MemberRoleOwner {
+		return nil, ErrInsufficientMemberRole
+	}
+
+	if err := s.memberRepo.UpdateRole(member.ID, role); err != nil {
+		return nil, err
+	}
+
+	member.Role = role
+	return member, nil
+}
+
+func (s *MembershipService) RemoveMember(actor *models.CompanyMember, memberID uint) error {
+	member, err := s.manageableMember(actor, memberID)
+	if err != nil {
+		return err
+	}
+
+	return s.memberRepo.Delete(member.ID)
+}
+
+// manageableMember loads a member of the actor's company that the actor may
+// change. Nobody can change the owner and only the owner can change admins.
+func (s *MembershipService) manageableMember(actor *models.CompanyMember, memberID uint) (*models.CompanyMember, error) {
+	member, err := s.memberRepo.GetByID(memberID)
+	if err != nil || member.CompanyID != actor.CompanyID {
+		return nil, ErrMemberNotFound
+	}
+
+	if member.Role == models.MemberRoleOwner {
+		return nil, ErrCannotModifyOwner
+	}
+	if member.Role == models.MemberRoleAdmin && actor.Role != models.MemberRoleOwner {
+		return nil, ErrInsufficientMemberRole
+	}
+
+	return member, nil
+}
+
+func (s *MembershipService) invitationMessage(company *models.Company, invitation *models.CompanyInvitation, token string) string {
+	link := s.settings.URL + "?token=" + url.QueryEscape(token)
+	return fmt.Sprintf(
+		"Halo,\n\nKamu diundang untuk bergabung dengan %s di JobFair sebagai %s. Masuk dengan akun perusahaan kamu lalu buka tautan berikut untuk menerima undangan:\n\n%s\n\nTautan ini berlaku sampai %s dan hanya bisa digunakan sekali. Abaikan email ini jika kamu tidak mengenal pengirimnya.\n",
+		company.Name, invitation.Role, link, invitation.ExpiresAt.Format("02 Jan 2006 15:04"),
+	)
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"jobfair-company-service/internal/models"
+)
+
+var (
+	ErrResumeNotFound       = errors.New("resume not found in your resume library")
+	ErrResumeLibraryOffline = errors.New("resume library is unavailable, try again later")
+)
+
+// StoredResume is the part of a resume library entry the company service
+// keeps a reference to. Parsed is nil when the file could not be parsed.
+type StoredResume struct {
+	ID       uint                 `json:"id"`
+	Name     string               `json:"name"`
+	FileName string               `json:"file_name"`
+	Parsed   *models.ParsedResume `json:"parsed"`
+}
+
+// ResumeClient talks to the resume library of the auth service through its
+// internal API. The library belongs to the job seeker, applications only
+// store the resume ID and ask for a download link when a recruiter opens it.
+type ResumeClient struct {
+	authServiceURL string
+	internalSecret string
+	client         *http.Client
+}
+
+func NewResumeClient(authServiceURL, internalSecret string) *ResumeClient {
+	return &ResumeClient{
+		authServiceURL: authServiceURL,
+		internalSecret: internalSecret,
+		client:         &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Resolve returns the resume a job seeker applies with, resumeID 0 picks the
+// default resume of the library
+func (c *ResumeClient) Resolve(userID, resumeID uint) (*StoredResume, error) {
+	endpoint := fmt.Sprintf("%s/internal/users/%d/resume?resume_id=%d", c.authServiceURL, userID, resumeID)
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resume StoredResume
+	if err := c.do(req, &resume); err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+// Upload stores a resume file sent with an application. The auth service
+// keeps it outside the job seeker's library and parses it like a library
+// resume.
+func (c *ResumeClient) Upload(userID uint, file *multipart.FileHeader) (*StoredResume, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filepath.Base(file.Filename))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, src); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/users/%d/resumes", c.authServiceURL, userID)
+	req, err := http.NewRequest(http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	var resume StoredResume
+	if err := c.do(req, &resume); err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+// Discard removes a resume stored by Upload when the application it was
+// uploaded with could not be saved
+func (c *ResumeClient) Discard(userID, resumeID uint) error {
+	endpoint := fmt.Sprintf("%s/internal/users/%d/resumes/%d", c.authServiceURL, userID, resumeID)
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.do(req, nil)
+}
+
+// DownloadLink asks for a time-limited link to a resume the user applied
+// with, which also works after the resume was removed from the library
+func (c *ResumeClient) DownloadLink(userID, resumeID uint) (*models.ResumeDownloadLink, error) {
+	payload, err := json.Marshal(map[string]uint{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/resumes/%d/download-link", c.authServiceURL, resumeID)
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var link models.ResumeDownloadLink
+	if err := c.do(req, &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (c *ResumeClient) do(req *http.Request, data interface{}) error {
+	req.Header.Set("X-Internal-Secret", c.internalSecret)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		log.Printf("Warning: resume library request failed: %v", err)
+		return ErrResumeLibraryOffline
+	}
+	defer resp.Body.Close()
+
+	body := struct {
+		Data    interface{} `json:"data"`
+		Message string      `json:"message"`
+	}{Data: data}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrResumeNotFound
+	case resp.StatusCode == http.StatusBadRequest:
+		// Rejected uploads, the message tells the applicant what is wrong
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Message == "" {
+			return fmt.Errorf("auth service returned status: %d", resp.StatusCode)
+		}
+		return errors.New(body.Message)
+	case resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated:
+		return fmt.Errorf("auth service returned status: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(&body)
+}
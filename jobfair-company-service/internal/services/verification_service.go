@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"jobfair-company-service/internal/models"
+	"jobfair-company-service/internal/repository"
+	"jobfair-company-service/internal/utils"
+)
+
+// Audit log actions
+const (
+	AuditActionVerificationApprove = "company.verification_approve"
+	AuditActionVerificationReject  = "company.verification_reject"
+)
+
+const maxVerificationDocuments = 10
+
+var (
+	ErrVerificationPending           = errors.New("a verification request is already under review")
+	ErrVerificationDocumentsRequired = errors.New("at least one verification document is required")
+	ErrTooManyVerificationDocuments  = fmt.Errorf("at most %d verification documents can be uploaded", maxVerificationDocuments)
+	ErrVerificationRequestNotFound   = errors.New("verification request not found")
+	ErrVerificationDocumentNotFound  = errors.New("verification document not found")
+	ErrVerificationNotPending        = repository.ErrVerificationNotPending
+)
+
+// VerificationService handles company verification requests. Companies
+// submit legal documents, admins approve or reject them, and an approval
+// sets the company's verification badge.
+type VerificationService struct {
+	verificationRepo *repository.CompanyVerificationRepository
+	companyRepo      *repository.CompanyRepository
+	uploadPath       string
+}
+
+func NewVerificationService(verificationRepo *repository.CompanyVerificationRepository, companyRepo *repository.CompanyRepository, uploadPath string) *VerificationService {
+	return &VerificationService{
+		verificationRepo: verificationRepo,
+		companyRepo:      companyRepo,
+		uploadPath:       uploadPath,
+	}
+}
+
+// SubmitRequest stores the documents, keyed by document type, and opens a
+// pending request
+func (s *VerificationService) SubmitRequest(companyID, userID uint, req *models.SubmitVerificationRequest, files map[string][]*multipart.FileHeader) (*models.CompanyVerificationRequest, error) {
+	company, err := s.companyRepo.GetByID(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+	if company.IsVerified {
+		return nil, ErrCompanyAlreadyVerified
+	}
+
+	pending, err := s.verificationRepo.HasPending(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrVerificationPending
+	}
+
+	count := 0
+	for _, documentType := range models.VerificationDocumentTypes {
+		for _, file := range files[documentType] {
+			if err := utils.ValidateFile(file, utils.DocumentConfig); err != nil {
+				return nil, fmt.Errorf("%s: %w", documentType, err)
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, ErrVerificationDocumentsRequired
+	}
+	if count > maxVerificationDocuments {
+		return nil, ErrTooManyVerificationDocuments
+	}
+
+	request := &models.CompanyVerificationRequest{
+		CompanyID:   companyID,
+		SubmittedBy: userID,
+		Notes:       req.Notes,
+		Status:      models.VerificationStatusPending,
+	}
+
+	dir := filepath.Join("verification", fmt.Sprintf("%d", companyID))
+	timestamp := time.Now().Unix()
+	for _, documentType := range models.VerificationDocumentTypes {
+		for i, file := range files[documentType] {
+			filename := fmt.Sprintf("%d_%s_%d%s", timestamp, documentType, i+1, utils.GetFileExtension(file.Filename))
+			if err := utils.SaveFile(file, filepath.Join(s.uploadPath, dir), filename); err != nil {
+				s.removeDocuments(request.Documents)
+				return nil, err
+			}
+
+			request.Documents = append(request.Documents, models.CompanyVerificationDocument{
+				DocumentType: documentType,
+				FileName:     filepath.Base(file.Filename),
+				FilePath:     filepath.Join(dir, filename),
+				FileSize:     file.Size,
+			})
+		}
+	}
+
+	if err := s.verificationRepo.Create(request); err != nil {
+		s.removeDocuments(request.Documents)
+		// Two concurrent submissions can both pass the check above
+		if strings.Contains(err.Error(), "idx_company_verification_requests_pending") {
+			return nil, ErrVerificationPending
+		}
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// GetLatestRequest returns the company's most recent request
+func (s *VerificationService) GetLatestRequest(companyID uint) (*models.CompanyVerificationRequest, error) {
+	request, err := s.verificationRepo.GetLatestByCompany(companyID)
+	if err != nil {
+		return nil, ErrVerificationRequestNotFound
+	}
+	return request, nil
+}
+
+func (s *VerificationService) GetRequest(id uint) (*models.CompanyVerificationRequest, error) {
+	request, err := s.verificationRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrVerificationRequestNotFound
+	}
+	return request, nil
+}
+
+func (s *VerificationService) ListRequests(status string, limit, offset int) ([]*models.CompanyVerificationRequest, int64, error) {
+	return s.verificationRepo.List(status, limit, offset)
+}
+
+// DocumentPath returns the file on disk and the original file name of a
+// document belonging to the request
+func (s *VerificationService) DocumentPath(requestID, documentID uint) (string, string, error) {
+	request, err := s.GetRequest(requestID)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, document := range request.Documents {
+		if document.ID == documentID {
+			return filepath.Join(s.uploadPath, document.FilePath), document.FileName, nil
+		}
+	}
+	return "", "", ErrVerificationDocumentNotFound
+}
+
+// ApproveRequest verifies the company with the given badge
+func (s *VerificationService) ApproveRequest(adminID, requestID uint, req *models.ApproveVerificationRequest, ipAddress string) (*models.CompanyVerificationRequest, error) {
+	request, err := s.GetRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.VerificationStatusPending {
+		return nil, ErrVerificationNotPending
+	}
+
+	badge := req.VerificationBadge
+	if badge == "" {
+		badge = defaultCompanyVerificationBadge
+	}
+
+	entry := auditEntry(adminID, AuditActionVerificationApprove, "company", request.CompanyID, "", ipAddress,
+		map[string]interface{}{"verification_request_id": request.ID, "is_verified": false},
+		map[string]interface{}{"verification_request_id": request.ID, "is_verified": true, "verification_badge": badge},
+	)
+	if err := s.verificationRepo.Approve(request, adminID, badge, entry); err != nil {
+		return nil, err
+	}
+
+	return s.GetRequest(requestID)
+}
+
+func (s *VerificationService) RejectRequest(adminID, requestID uint, reason, ipAddress string) (*models.CompanyVerificationRequest, error) {
+	request, err := s.GetRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.VerificationStatusPending {
+		return nil, ErrVerificationNotPending
+	}
+
+	entry := auditEntry(adminID, AuditActionVerificationReject, "company", request.CompanyID, reason, ipAddress,
+		map[string]interface{}{"verification_request_id": request.ID, "status": request.Status},
+		map[string]interface{}{"verification_request_id": request.ID, "status": models.VerificationStatusRejected},
+	)
+	if err := s.verificationRepo.Reject(request, adminID, reason, entry); err != nil {
+		return nil, err
+	}
+
+	return s.GetRequest(requestID)
+}
+
+func (s *VerificationService) removeDocuments(documents []models.CompanyVerificationDocument) {
+	for _, document := range documents {
+		os.Remove(filepath.Join(s.uploadPath, document.FilePath))
+	}
+}
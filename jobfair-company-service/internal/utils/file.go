@@ -3,35 +3,63 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/gabriel-vasile/mimetype"
 )
 
+var ErrFileTypeMismatch = errors.New("file content does not match its extension")
+
 type FileUploadConfig struct {
 	AllowedExtensions []string
-	MaxFileSize       int64
-	UploadPath        string
+	// MIMETypes lists the content types detected from the file bytes that
+	// are accepted for each extension
+	MIMETypes   map[string][]string
+	MaxFileSize int64
+	UploadPath  string
 }
 
 var (
 	ImageConfig = FileUploadConfig{
 		AllowedExtensions: []string{".jpg", ".jpeg", ".png", ".gif", ".webp"},
-		MaxFileSize:       10 * 1024 * 1024,
-		UploadPath:        "/uploads/images",
+		MIMETypes: map[string][]string{
+			".jpg":  {"image/jpeg"},
+			".jpeg": {"image/jpeg"},
+			".png":  {"image/png"},
+			".gif":  {"image/gif"},
+			".webp": {"image/webp"},
+		},
+		MaxFileSize: 10 * 1024 * 1024,
+		UploadPath:  "/uploads/images",
 	}
 
 	VideoConfig = FileUploadConfig{
 		AllowedExtensions: []string{".mp4", ".avi", ".mov", ".webm"},
-		MaxFileSize:       50 * 1024 * 1024,
-		UploadPath:        "/uploads/videos",
+		MIMETypes: map[string][]string{
+			".mp4":  {"video/mp4"},
+			".avi":  {"video/x-msvideo"},
+			".mov":  {"video/quicktime", "video/mp4"},
+			".webm": {"video/webm"},
+		},
+		MaxFileSize: 50 * 1024 * 1024,
+		UploadPath:  "/uploads/videos",
 	}
 
 	DocumentConfig = FileUploadConfig{
 		AllowedExtensions: []string{".pdf", ".doc", ".docx"},
-		MaxFileSize:       5 * 1024 * 1024,
-		UploadPath:        "/uploads/documents",
+		MIMETypes: map[string][]string{
+			".pdf": {"application/pdf"},
+			// Older Word files are only recognised as OLE containers
+			".doc":  {"application/msword", "application/x-ole-storage"},
+			".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		},
+		MaxFileSize: 5 * 1024 * 1024,
+		UploadPath:  "/uploads/documents",
 	}
 )
 
@@ -53,7 +81,52 @@ func ValidateFile(file *multipart.FileHeader, config FileUploadConfig) error {
 		return errors.New("file type not allowed")
 	}
 
-	return nil
+	// The extension and Content-Type header are chosen by the client, only
+	// the bytes tell what was really uploaded
+	detected, err := DetectMIMEType(file)
+	if err != nil {
+		return err
+	}
+	for _, allowed := range config.MIMETypes[ext] {
+		if detected.Is(allowed) {
+			return nil
+		}
+	}
+
+	return ErrFileTypeMismatch
+}
+
+// DetectMIMEType sniffs the content type of an uploaded file from its bytes.
+func DetectMIMEType(file *multipart.FileHeader) (*mimetype.MIME, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return mimetype.DetectReader(src)
+}
+
+// SaveFile writes the uploaded file to dir/filename, creating dir if needed.
+func SaveFile(file *multipart.FileHeader, dir string, filename string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(filepath.Join(dir, filename))
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
 }
 
 func GenerateFileName(originalName string, prefix string) string {
@@ -0,0 +1,9 @@
+ALTER TABLE job_applications DROP COLUMN IF EXISTS withdrawn_at;
+
+UPDATE job_applications SET status = 'rejected' WHERE status = 'withdrawn';
+
+ALTER TABLE job_applications DROP CONSTRAINT IF EXISTS job_applications_status_check;
+ALTER TABLE job_applications ADD CONSTRAINT job_applications_status_check
+    CHECK (status IN ('applied', 'shortlisted', 'interview', 'hired', 'rejected'));
+
+COMMENT ON COLUMN job_applications.status IS 'Application status: applied, shortlisted, interview, hired, rejected';
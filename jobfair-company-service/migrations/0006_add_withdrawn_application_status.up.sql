@@ -0,0 +1,10 @@
+-- Allow job seekers to withdraw their own applications
+ALTER TABLE job_applications DROP CONSTRAINT IF EXISTS job_applications_status_check;
+ALTER TABLE job_applications ADD CONSTRAINT job_applications_status_check
+    CHECK (status IN ('applied', 'shortlisted', 'interview', 'hired', 'rejected', 'withdrawn'));
+
+ALTER TABLE job_applications ADD COLUMN IF NOT EXISTS withdrawn_at TIMESTAMP;
+
+-- Comments
+COMMENT ON COLUMN job_applications.status IS 'Application status: applied, shortlisted, interview, hired, rejected, withdrawn';
+COMMENT ON COLUMN job_applications.withdrawn_at IS 'When the applicant withdrew the application';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_application_status_history_created_at;
+DROP INDEX IF EXISTS idx_application_status_history_application_id;
+
+DROP TABLE IF EXISTS application_status_history;
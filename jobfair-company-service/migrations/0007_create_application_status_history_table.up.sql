@@ -0,0 +1,37 @@
+-- Create application_status_history table
+CREATE TABLE IF NOT EXISTS application_status_history (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL,
+
+    -- Transition
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL CHECK (to_status IN ('applied', 'shortlisted', 'interview', 'hired', 'rejected', 'withdrawn')),
+
+    -- Actor
+    changed_by INTEGER NOT NULL,
+    changed_by_type VARCHAR(20) NOT NULL CHECK (changed_by_type IN ('job_seeker', 'company')),
+    note TEXT,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_application_status_history_application_id
+        FOREIGN KEY (application_id)
+        REFERENCES job_applications(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_application_status_history_application_id ON application_status_history(application_id);
+CREATE INDEX idx_application_status_history_created_at ON application_status_history(created_at);
+
+-- Backfill the initial submission for existing applications
+INSERT INTO application_status_history (application_id, from_status, to_status, changed_by, changed_by_type, created_at)
+SELECT id, NULL, 'applied', user_id, 'job_seeker', applied_at
+FROM job_applications;
+
+-- Comments
+COMMENT ON TABLE application_status_history IS 'Timeline of status changes for job applications';
+COMMENT ON COLUMN application_status_history.from_status IS 'Previous status, NULL for the initial submission';
+COMMENT ON COLUMN application_status_history.changed_by IS 'Reference to users table in auth service';
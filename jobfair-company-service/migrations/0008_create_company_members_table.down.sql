@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_company_invitations_pending_email;
+DROP INDEX IF EXISTS idx_company_invitations_company_id;
+
+DROP TABLE IF EXISTS company_invitations;
+
+DROP TRIGGER IF EXISTS update_company_members_updated_at ON company_members;
+
+DROP INDEX IF EXISTS idx_company_members_owner;
+DROP INDEX IF EXISTS idx_company_members_company_id;
+
+DROP TABLE IF EXISTS company_members;
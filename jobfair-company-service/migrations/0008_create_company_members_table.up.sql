@@ -0,0 +1,85 @@
+-- Create company_members table, a company can have several logins with different roles
+CREATE TABLE IF NOT EXISTS company_members (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    email VARCHAR(255),
+
+    -- Access
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'recruiter', 'viewer')),
+    invited_by INTEGER,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_company_members_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE,
+
+    -- A user belongs to at most one company
+    CONSTRAINT company_members_user_id_unique UNIQUE (user_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_company_members_company_id ON company_members(company_id);
+
+-- Exactly one owner per company
+CREATE UNIQUE INDEX idx_company_members_owner ON company_members(company_id) WHERE role = 'owner';
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_company_members_updated_at
+BEFORE UPDATE ON company_members
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Backfill the existing 1:1 company owners
+INSERT INTO company_members (company_id, user_id, role, created_at)
+SELECT id, user_id, 'owner', created_at
+FROM companies
+WHERE deleted_at IS NULL;
+
+-- Create company_invitations table
+CREATE TABLE IF NOT EXISTS company_invitations (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'recruiter', 'viewer')),
+    token_hash VARCHAR(64) NOT NULL,
+    invited_by INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+
+    -- Outcome
+    accepted_at TIMESTAMP,
+    accepted_by INTEGER,
+    revoked_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_company_invitations_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE,
+
+    -- Unique constraint
+    CONSTRAINT company_invitations_token_hash_unique UNIQUE (token_hash)
+);
+
+-- Create indexes
+CREATE INDEX idx_company_invitations_company_id ON company_invitations(company_id, created_at);
+
+-- Only one pending invitation per email and company
+CREATE UNIQUE INDEX idx_company_invitations_pending_email ON company_invitations(company_id, LOWER(email))
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+-- Comments
+COMMENT ON TABLE company_members IS 'Users that can act on behalf of a company';
+COMMENT ON COLUMN company_members.user_id IS 'Reference to users table in auth service (loose coupling)';
+COMMENT ON COLUMN company_members.role IS 'Member role: owner, admin, recruiter, viewer';
+COMMENT ON COLUMN company_members.email IS 'Email the invitation was sent to, NULL for the owner';
+COMMENT ON TABLE company_invitations IS 'Email invitations to join a company';
+COMMENT ON COLUMN company_invitations.token_hash IS 'SHA-256 of the invitation token, the token itself is only emailed';
@@ -0,0 +1,8 @@
+ALTER TABLE jobs DROP COLUMN IF EXISTS moderation_reason;
+ALTER TABLE jobs DROP COLUMN IF EXISTS moderated_at;
+
+DROP INDEX IF EXISTS idx_admin_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_admin_audit_logs_admin_id;
+DROP INDEX IF EXISTS idx_admin_audit_logs_target;
+
+DROP TABLE IF EXISTS admin_audit_logs;
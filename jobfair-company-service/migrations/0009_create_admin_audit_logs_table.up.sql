@@ -0,0 +1,34 @@
+-- Create admin_audit_logs table, every admin moderation action is recorded
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL,
+    action VARCHAR(50) NOT NULL,
+
+    -- Target
+    target_type VARCHAR(30) NOT NULL,
+    target_id INTEGER NOT NULL,
+
+    -- Context
+    reason TEXT,
+    details JSONB,
+    ip_address VARCHAR(45),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_admin_audit_logs_target ON admin_audit_logs(target_type, target_id);
+CREATE INDEX idx_admin_audit_logs_admin_id ON admin_audit_logs(admin_id);
+CREATE INDEX idx_admin_audit_logs_created_at ON admin_audit_logs(created_at);
+
+-- Jobs closed by an admin cannot be republished by the company
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMP;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS moderation_reason TEXT;
+
+-- Comments
+COMMENT ON TABLE admin_audit_logs IS 'Append-only trail of admin moderation actions';
+COMMENT ON COLUMN admin_audit_logs.admin_id IS 'Reference to users table in auth service';
+COMMENT ON COLUMN admin_audit_logs.action IS 'company.verify, company.unverify, company.feature, company.unfeature or job.force_close';
+COMMENT ON COLUMN admin_audit_logs.details IS 'JSON state before and after the action';
+COMMENT ON COLUMN jobs.moderated_at IS 'When an admin force-closed the job';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_company_verification_documents_request_id;
+DROP TABLE IF EXISTS company_verification_documents;
+
+DROP TRIGGER IF EXISTS update_company_verification_requests_updated_at ON company_verification_requests;
+DROP INDEX IF EXISTS idx_company_verification_requests_pending;
+DROP INDEX IF EXISTS idx_company_verification_requests_status;
+DROP INDEX IF EXISTS idx_company_verification_requests_company_id;
+DROP TABLE IF EXISTS company_verification_requests;
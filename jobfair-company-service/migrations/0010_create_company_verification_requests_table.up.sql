@@ -0,0 +1,65 @@
+-- Create company_verification_requests table, a company submits legal documents and an admin reviews them
+CREATE TABLE IF NOT EXISTS company_verification_requests (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER NOT NULL,
+    submitted_by INTEGER NOT NULL,
+    notes TEXT,
+
+    -- Review
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by INTEGER,
+    reviewed_at TIMESTAMP,
+    rejection_reason TEXT,
+    verification_badge VARCHAR(50),
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_company_verification_requests_company_id
+        FOREIGN KEY (company_id)
+        REFERENCES companies(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_company_verification_requests_company_id ON company_verification_requests(company_id);
+CREATE INDEX idx_company_verification_requests_status ON company_verification_requests(status);
+
+-- At most one request under review per company
+CREATE UNIQUE INDEX idx_company_verification_requests_pending ON company_verification_requests(company_id) WHERE status = 'pending';
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_company_verification_requests_updated_at
+BEFORE UPDATE ON company_verification_requests
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Create company_verification_documents table
+CREATE TABLE IF NOT EXISTS company_verification_documents (
+    id SERIAL PRIMARY KEY,
+    request_id INTEGER NOT NULL,
+    document_type VARCHAR(30) NOT NULL CHECK (document_type IN ('business_license', 'tax_id', 'deed_of_establishment', 'other')),
+    file_name VARCHAR(255) NOT NULL,
+    file_path VARCHAR(500) NOT NULL,
+    file_size BIGINT NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Key
+    CONSTRAINT fk_company_verification_documents_request_id
+        FOREIGN KEY (request_id)
+        REFERENCES company_verification_requests(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_company_verification_documents_request_id ON company_verification_documents(request_id);
+
+-- Comments
+COMMENT ON TABLE company_verification_requests IS 'Company verification submissions and their review outcome';
+COMMENT ON COLUMN company_verification_requests.submitted_by IS 'Reference to users table in auth service';
+COMMENT ON COLUMN company_verification_requests.reviewed_by IS 'Admin user who approved or rejected the request';
+COMMENT ON COLUMN company_verification_documents.file_path IS 'Path under UPLOAD_PATH, documents are never served publicly';
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_jobs_slug_unique;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_title_fulltext ON jobs USING GIN (to_tsvector('english', title));
+CREATE INDEX IF NOT EXISTS idx_jobs_description_fulltext ON jobs USING GIN (to_tsvector('english', description));
+
+DROP INDEX IF EXISTS idx_jobs_salary_max;
+DROP INDEX IF EXISTS idx_jobs_search_vector;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS jobs_skills_text(TEXT[]);
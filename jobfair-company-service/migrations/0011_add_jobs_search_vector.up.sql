@@ -0,0 +1,38 @@
+-- array_to_string is only STABLE, generated columns need an IMMUTABLE expression
+CREATE OR REPLACE FUNCTION jobs_skills_text(skills TEXT[])
+RETURNS TEXT AS $$
+    SELECT COALESCE(array_to_string(skills, ' '), '')
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Search document for the public job board, weighted title > skills > requirements > description.
+-- The 'simple' configuration does not stem, postings are written in both Indonesian and English.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('simple', jobs_skills_text(skills)), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(requirements, '')), 'C') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'D')
+) STORED;
+
+-- Create indexes
+CREATE INDEX idx_jobs_search_vector ON jobs USING GIN (search_vector);
+CREATE INDEX idx_jobs_salary_max ON jobs(salary_max);
+
+-- Replaced by search_vector
+DROP INDEX IF EXISTS idx_jobs_title_fulltext;
+DROP INDEX IF EXISTS idx_jobs_description_fulltext;
+
+-- Slugs identify public job pages, de-duplicate them before enforcing uniqueness
+UPDATE jobs
+SET slug = slug || '-' || id
+WHERE deleted_at IS NULL
+  AND slug IS NOT NULL
+  AND id NOT IN (
+      SELECT MIN(id) FROM jobs
+      WHERE deleted_at IS NULL AND slug IS NOT NULL
+      GROUP BY slug
+  );
+
+CREATE UNIQUE INDEX idx_jobs_slug_unique ON jobs(slug) WHERE deleted_at IS NULL;
+
+-- Comments
+COMMENT ON COLUMN jobs.search_vector IS 'Full-text search document, maintained by Postgres';
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_company_media_company_type_order;
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS video_urls TEXT[];
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS gallery_urls TEXT[];
+
+UPDATE companies c SET
+    gallery_urls = (
+        SELECT array_agg(m.file_url ORDER BY m.display_order, m.id)
+        FROM company_media m
+        WHERE m.company_id = c.id AND m.media_type = 'gallery' AND m.deleted_at IS NULL
+    ),
+    video_urls = (
+        SELECT array_agg(m.file_url ORDER BY m.display_order, m.id)
+        FROM company_media m
+        WHERE m.company_id = c.id AND m.media_type = 'video' AND m.deleted_at IS NULL
+    );
+
+DELETE FROM company_media WHERE media_type IN ('gallery', 'video');
+
+CREATE INDEX idx_companies_video_urls ON companies USING GIN (video_urls);
+COMMENT ON COLUMN companies.video_urls IS 'Array of video URLs for virtual booth';
@@ -0,0 +1,17 @@
+-- Gallery images and videos become company_media rows so they can be captioned, reordered and removed
+INSERT INTO company_media (company_id, media_type, file_name, file_url, display_order)
+SELECT c.id, 'gallery', regexp_replace(m.url, '^.*/', ''), m.url, m.position - 1
+FROM companies c, unnest(c.gallery_urls) WITH ORDINALITY AS m(url, position)
+WHERE c.gallery_urls IS NOT NULL;
+
+INSERT INTO company_media (company_id, media_type, file_name, file_url, display_order)
+SELECT c.id, 'video', regexp_replace(m.url, '^.*/', ''), m.url, m.position - 1
+FROM companies c, unnest(c.video_urls) WITH ORDINALITY AS m(url, position)
+WHERE c.video_urls IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_companies_video_urls;
+ALTER TABLE companies DROP COLUMN IF EXISTS gallery_urls;
+ALTER TABLE companies DROP COLUMN IF EXISTS video_urls;
+
+-- Listing a company's media of one type in display order
+CREATE INDEX idx_company_media_company_type_order ON company_media(company_id, media_type, display_order) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE job_applications DROP COLUMN IF EXISTS resume_id;
@@ -0,0 +1,3 @@
+-- Resume from the applicant's library (auth service resumes.id) the application was submitted with.
+-- NULL for applications with an uploaded file or an external resume_url.
+ALTER TABLE job_applications ADD COLUMN IF NOT EXISTS resume_id INTEGER;
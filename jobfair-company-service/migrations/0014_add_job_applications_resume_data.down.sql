@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_job_applications_resume_skills;
+
+ALTER TABLE job_applications DROP COLUMN IF EXISTS resume_skills;
+ALTER TABLE job_applications DROP COLUMN IF EXISTS resume_data;
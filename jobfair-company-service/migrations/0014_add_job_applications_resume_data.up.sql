@@ -0,0 +1,8 @@
+-- Parsed resume (contact, skills, education, work history) taken when the application was submitted
+ALTER TABLE job_applications ADD COLUMN IF NOT EXISTS resume_data JSONB;
+
+-- Lower-cased skills of the parsed resume, recruiters filter applicants by them
+ALTER TABLE job_applications ADD COLUMN IF NOT EXISTS resume_skills TEXT[];
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_job_applications_resume_skills ON job_applications USING GIN (resume_skills);
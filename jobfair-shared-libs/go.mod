@@ -0,0 +1,8 @@
+module jobfair-shared-libs
+
+go 1.23
+
+require (
+	github.com/golang-jwt/jwt/v5 v5.0.0
+	golang.org/x/image v0.23.0
+)
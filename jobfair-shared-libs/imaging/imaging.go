@@ -0,0 +1,201 @@
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strings"
+
+	"jobfair-shared-libs/storage"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	ErrFormatMismatch   = errors.New("file content does not match its extension")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+// maxPixels guards against decompression bombs, a small file can declare
+// a huge canvas that would be allocated in full when decoded
+const maxPixels = 40_000_000
+
+const jpegQuality = 85
+
+// Variant is a stored rendition of an uploaded image. MaxSize bounds the
+// longest side in pixels, 0 keeps the original dimensions.
+type Variant struct {
+	Name    string
+	MaxSize int
+}
+
+const (
+	VariantThumbnail = "thumbnail"
+	VariantMedium    = "medium"
+	VariantOriginal  = "original"
+)
+
+var Variants = []Variant{
+	{Name: VariantThumbnail, MaxSize: 200},
+	{Name: VariantMedium, MaxSize: 800},
+	{Name: VariantOriginal},
+}
+
+// formatExtensions lists the extensions each decodable format may be
+// uploaded with
+var formatExtensions = map[string][]string{
+	"jpeg": {".jpg", ".jpeg"},
+	"png":  {".png"},
+	"gif":  {".gif"},
+	"webp": {".webp"},
+}
+
+// CheckFormat detects the real format of data from its bytes and ensures
+// ext (lowercase, with dot) belongs to it.
+func CheckFormat(data []byte, ext string) (string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", ErrUnsupportedImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return "", ErrImageTooLarge
+	}
+
+	exts, ok := formatExtensions[format]
+	if !ok {
+		return "", ErrUnsupportedImage
+	}
+	for _, e := range exts {
+		if e == ext {
+			return format, nil
+		}
+	}
+	return "", ErrFormatMismatch
+}
+
+// Rendition is one encoded variant.
+type Rendition struct {
+	Name        string
+	Data        []byte
+	ContentType string
+	Ext         string
+}
+
+// Process decodes an image, applies its EXIF orientation and re-encodes it
+// once per variant. Re-encoding drops every metadata block (EXIF, XMP,
+// ICC, text chunks), so GPS positions and camera details never reach
+// storage. JPEG stays JPEG, everything else becomes PNG to keep
+// transparency; animated GIFs keep their first frame only.
+func Process(data []byte) ([]Rendition, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	renditions := make([]Rendition, 0, len(Variants))
+	for _, variant := range Variants {
+		var buf bytes.Buffer
+		rendition := Rendition{Name: variant.Name}
+		resized := fit(img, variant.MaxSize)
+
+		if format == "jpeg" {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegQuality})
+			rendition.ContentType, rendition.Ext = "image/jpeg", ".jpg"
+		} else {
+			err = png.Encode(&buf, resized)
+			rendition.ContentType, rendition.Ext = "image/png", ".png"
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rendition.Data = buf.Bytes()
+		renditions = append(renditions, rendition)
+	}
+
+	return renditions, nil
+}
+
+// Store processes an uploaded image and stores every variant under
+// prefix/<sha256 of the upload>/<variant><ext>, so uploading the same file
+// again reuses the same keys. It returns the key of each variant by name.
+func Store(ctx context.Context, store storage.Storage, prefix string, data []byte) (map[string]string, error) {
+	renditions, err := Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	dir := prefix + "/" + hex.EncodeToString(sum[:])
+
+	keys := make(map[string]string, len(renditions))
+	for _, rendition := range renditions {
+		key := dir + "/" + rendition.Name + rendition.Ext
+		if err := store.Put(ctx, key, bytes.NewReader(rendition.Data), int64(len(rendition.Data)), rendition.ContentType); err != nil {
+			return nil, fmt.Errorf("store %s variant: %w", rendition.Name, err)
+		}
+		keys[rendition.Name] = key
+	}
+
+	return keys, nil
+}
+
+// VariantKeys returns the keys of every variant stored next to key by
+// Store. Keys not laid out by Store are returned as they are.
+func VariantKeys(key string) []string {
+	dir, file := path.Split(key)
+	ext := path.Ext(file)
+	if strings.TrimSuffix(file, ext) != VariantOriginal {
+		return []string{key}
+	}
+
+	keys := make([]string, 0, len(Variants))
+	for _, variant := range Variants {
+		keys = append(keys, dir+variant.Name+ext)
+	}
+	return keys
+}
+
+// VariantURLs maps variant names to the URLs they are served under.
+func VariantURLs(keys map[string]string) map[string]string {
+	urls := make(map[string]string, len(keys))
+	for name, key := range keys {
+		urls[name] = storage.URL(key)
+	}
+	return urls
+}
+
+// fit scales img down so its longest side is at most maxSize. Smaller
+// images are never enlarged.
+func fit(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxSize == 0 || (width <= maxSize && height <= maxSize) {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,149 @@
+package jwks
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRefetchInterval limits refetches caused by unknown kids so forged
+// tokens with random kids cannot flood the auth service.
+const minRefetchInterval = 30 * time.Second
+
+// KeySet caches the auth service public keys from /.well-known/jwks.json to
+// verify RS256 access tokens. Keys are refreshed every refreshInterval and
+// when a token uses a kid that is not known yet (a new key after rotation).
+type KeySet struct {
+	url             string
+	refreshInterval time.Duration
+	client          *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+}
+
+func NewKeySet(url string, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		url:             url,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: 5 * time.Second},
+		keys:            make(map[string]*rsa.PublicKey),
+	}
+}
+
+// KeyFunc is the jwt.Keyfunc for access tokens. Only RS256 is accepted, so
+// old HS256 tokens and tokens "signed" with the public key as an HMAC secret
+// are rejected.
+func (j *KeySet) KeyFunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	key, ok, stale := j.lookup(kid)
+	if !ok || stale {
+		if err := j.refresh(!ok); err != nil && !ok {
+			return nil, err
+		}
+		key, ok, _ = j.lookup(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (j *KeySet) lookup(kid string) (*rsa.PublicKey, bool, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	key, ok := j.keys[kid]
+	return key, ok, time.Since(j.fetchedAt) > j.refreshInterval
+}
+
+// refresh refetches the JWKS. On failure the previous keys stay in use until
+// the auth service is reachable again.
+func (j *KeySet) refresh(unknownKID bool) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// Another request may have refreshed while we waited for the lock
+	if !unknownKID && time.Since(j.fetchedAt) <= j.refreshInterval {
+		return nil
+	}
+	if time.Since(j.lastAttempt) < minRefetchInterval {
+		return errors.New("jwks refetched too recently")
+	}
+	j.lastAttempt = time.Now()
+
+	keys, err := j.fetch()
+	if err != nil {
+		return err
+	}
+
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	return nil
+}
+
+func (j *KeySet) fetch() (map[string]*rsa.PublicKey, error) {
+	resp, err := j.client.Get(j.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks endpoint returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(body.Keys))
+	for _, k := range body.Keys {
+		if k.Kty != "RSA" || k.Kid == "" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
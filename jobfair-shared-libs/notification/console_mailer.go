@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+	"log"
+)
+
+// ConsoleMailer logs emails instead of delivering them. Development only.
+type ConsoleMailer struct{}
+
+func NewConsoleMailer() *ConsoleMailer {
+	return &ConsoleMailer{}
+}
+
+func (m *ConsoleMailer) SendMail(_ context.Context, to, subject, body string) error {
+	log.Printf("📧 [console mail] to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ConsoleOTPSender logs the OTP instead of delivering it. Development only.
+type ConsoleOTPSender struct{}
+
+func NewConsoleOTPSender() *ConsoleOTPSender {
+	return &ConsoleOTPSender{}
+}
+
+func (s *ConsoleOTPSender) SendOTP(_ context.Context, phoneNumber, code string, ttl time.Duration) error {
+	log.Printf("📱 [console OTP] to=%s message=%q", phoneNumber, otpMessage(code, ttl))
+	return nil
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer writes each email as an .eml file into a local outbox directory
+// so links can be opened by hand during local development.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) SendMail(_ context.Context, to, subject, body string) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), sanitizeFileName(to))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, buildMessage(m.from, to, subject, body), 0o644); err != nil {
+		return err
+	}
+
+	log.Printf("📧 [file mail] to=%s subject=%q written to %s", to, subject, path)
+	return nil
+}
+
+func sanitizeFileName(value string) string {
+	out := []rune(value)
+	for i, r := range out {
+		if r == '/' || r == '\\' || r == ':' {
+			out[i] = '_'
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+)
+
+// Mailer delivers a plain text email.
+type Mailer interface {
+	SendMail(ctx context.Context, to, subject, body string) error
+}
+
+type MailerConfig struct {
+	Provider string
+	From     string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	OutboxDir string
+}
+
+// NewMailer picks the implementation configured by MAIL_PROVIDER.
+func NewMailer(cfg MailerConfig) (Mailer, error) {
+	switch cfg.Provider {
+	case "", "console":
+		return NewConsoleMailer(), nil
+	case "file":
+		if cfg.OutboxDir == "" {
+			return nil, fmt.Errorf("MAIL_OUTBOX_DIR is required for the file mail provider")
+		}
+		return NewFileMailer(cfg.OutboxDir, cfg.From), nil
+	case "smtp":
+		if cfg.SMTPHost == "" || cfg.From == "" {
+			return nil, fmt.Errorf("SMTP_HOST and MAIL_FROM are required for the smtp mail provider")
+		}
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	default:
+		return nil, fmt.Errorf("unknown mail provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OTPSender delivers a one-time password to a phone number.
+type OTPSender interface {
+	SendOTP(ctx context.Context, phoneNumber, code string, ttl time.Duration) error
+}
+
+type OTPSenderConfig struct {
+	Provider string
+	// Development allows the console sender, which writes codes to the log
+	Development bool
+
+	SMSGatewayURL    string
+	SMSGatewayAPIKey string
+	SMSSenderID      string
+
+	WhatsAppAPIURL           string
+	WhatsAppPhoneNumberID    string
+	WhatsAppAccessToken      string
+	WhatsAppTemplateName     string
+	WhatsAppTemplateLanguage string
+}
+
+// NewOTPSender picks the implementation configured by OTP_PROVIDER. Outside
+// development a real provider is required.
+func NewOTPSender(cfg OTPSenderConfig) (OTPSender, error) {
+	switch cfg.Provider {
+	case "", "console":
+		if !cfg.Development {
+			return nil, fmt.Errorf("OTP_PROVIDER must be sms or whatsapp outside development, the console provider logs OTP codes")
+		}
+		return NewConsoleOTPSender(), nil
+	case "sms":
+		if cfg.SMSGatewayURL == "" {
+			return nil, fmt.Errorf("SMS_GATEWAY_URL is required for the sms OTP provider")
+		}
+		return NewSMSOTPSender(cfg.SMSGatewayURL, cfg.SMSGatewayAPIKey, cfg.SMSSenderID), nil
+	case "whatsapp":
+		if cfg.WhatsAppPhoneNumberID == "" || cfg.WhatsAppAccessToken == "" || cfg.WhatsAppTemplateName == "" {
+			return nil, fmt.Errorf("WHATSAPP_PHONE_NUMBER_ID, WHATSAPP_ACCESS_TOKEN and WHATSAPP_TEMPLATE_NAME are required for the whatsapp OTP provider")
+		}
+		return NewWhatsAppOTPSender(cfg.WhatsAppAPIURL, cfg.WhatsAppPhoneNumberID, cfg.WhatsAppAccessToken, cfg.WhatsAppTemplateName, cfg.WhatsAppTemplateLanguage), nil
+	default:
+		return nil, fmt.Errorf("unknown OTP provider %q", cfg.Provider)
+	}
+}
+
+func otpMessage(code string, ttl time.Duration) string {
+	return fmt.Sprintf("Kode verifikasi JobFair kamu: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", code, int(ttl.Minutes()))
+}
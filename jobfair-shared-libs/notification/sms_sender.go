@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SMSOTPSender posts the OTP to a generic HTTP SMS gateway:
+//
+//	POST <url> {"to": "...", "from": "...", "message": "..."}
+//	Authorization: Bearer <api key>
+type SMSOTPSender struct {
+	url      string
+	apiKey   string
+	senderID string
+	client   *http.Client
+}
+
+func NewSMSOTPSender(url, apiKey, senderID string) *SMSOTPSender {
+	return &SMSOTPSender{
+		url:      url,
+		apiKey:   apiKey,
+		senderID: senderID,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *SMSOTPSender) SendOTP(ctx context.Context, phoneNumber, code string, ttl time.Duration) error {
+	payload := map[string]string{
+		"to":      phoneNumber,
+		"from":    s.senderID,
+		"message": otpMessage(code, ttl),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway returned status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// smtpTimeout bounds a whole delivery when ctx has no earlier deadline,
+// net/smtp itself never times out
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer sends email through an SMTP relay using PLAIN auth when a
+// username is configured. STARTTLS is used whenever the server offers it.
+type SMTPMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	// from is the From header, envelopeFrom only its address for MAIL FROM
+	from         string
+	envelopeFrom string
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) (*SMTPMailer, error) {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_FROM %q: %w", from, err)
+	}
+	if port == 0 {
+		port = 587
+	}
+	return &SMTPMailer{
+		addr:         net.JoinHostPort(host, strconv.Itoa(port)),
+		host:         host,
+		username:     username,
+		password:     password,
+		from:         address.String(),
+		envelopeFrom: address.Address,
+	}, nil
+}
+
+func (m *SMTPMailer) SendMail(ctx context.Context, to, subject, body string) error {
+	if err := m.send(ctx, to, buildMessage(m.from, to, subject, body)); err != nil {
+		return fmt.Errorf("smtp send failed: %w", err)
+	}
+	return nil
+}
+
+// send is smtp.SendMail on a connection that honours ctx and smtpTimeout
+func (m *SMTPMailer) send(ctx context.Context, to string, msg []byte) error {
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(smtpTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("server does not support AUTH")
+		}
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.envelopeFrom); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage renders a minimal RFC 5322 plain text message.
+func buildMessage(from, to, subject, body string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
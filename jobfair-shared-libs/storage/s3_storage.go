@@ -0,0 +1,329 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	s3MaxPresignTTL    = 7 * 24 * time.Hour
+)
+
+// S3Storage talks to an S3 compatible API (AWS S3, MinIO) using Signature
+// Version 4, without pulling in an SDK.
+type S3Storage struct {
+	endpoint        *url.URL
+	publicEndpoint  *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	forcePathStyle  bool
+	client          *http.Client
+	now             func() time.Time
+}
+
+func NewS3Storage(cfg Config) (*S3Storage, error) {
+	endpoint, err := parseEndpoint(cfg.S3Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	publicEndpoint := endpoint
+	if cfg.S3PublicEndpoint != "" {
+		if publicEndpoint, err = parseEndpoint(cfg.S3PublicEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	region := cfg.S3Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &S3Storage{
+		endpoint:        endpoint,
+		publicEndpoint:  publicEndpoint,
+		region:          region,
+		bucket:          cfg.S3Bucket,
+		accessKeyID:     cfg.S3AccessKeyID,
+		secretAccessKey: cfg.S3SecretAccessKey,
+		forcePathStyle:  cfg.S3ForcePathStyle,
+		// No overall timeout, uploads are streamed and may be large. Callers
+		// bound requests with the context.
+		client: &http.Client{},
+		now:    time.Now,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if !ValidKey(key) {
+		return ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(s.endpoint, key).String(), r)
+	if err != nil {
+		return err
+	}
+	// A known length avoids chunked uploads, which S3 rejects
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, s3UnsignedPayload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError("put", key, resp)
+	}
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if !ValidKey(key) {
+		return nil, nil, ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(s.endpoint, key).String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.sign(req, s3EmptyPayloadHash)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, nil, s.responseError("get", key, resp)
+	}
+
+	object := &Object{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		object.ModTime = modTime
+	}
+
+	return resp.Body, object, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if !ValidKey(key) {
+		return ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(s.endpoint, key).String(), nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, s3EmptyPayloadHash)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 204 whether or not the object existed
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.responseError("delete", key, resp)
+	}
+	return nil
+}
+
+// SignedURL returns a presigned GET URL valid for ttl (at most 7 days).
+func (s *S3Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+	if ttl <= 0 || ttl > s3MaxPresignTTL {
+		return "", fmt.Errorf("presigned URL TTL must be between 1s and %s", s3MaxPresignTTL)
+	}
+
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+
+	target := s.objectURL(s.publicEndpoint, key)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.accessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		target.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + target.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, amzDate, scope, canonicalRequest))
+	target.RawQuery = canonicalQuery(query)
+	return target.String(), nil
+}
+
+func (s *S3Storage) objectURL(endpoint *url.URL, key string) *url.URL {
+	target := *endpoint
+	escapedKey := escapePath(key)
+	basePath := strings.TrimSuffix(target.Path, "/")
+
+	if s.forcePathStyle {
+		target.Path = basePath + "/" + s.bucket + "/" + key
+		target.RawPath = basePath + "/" + escapePath(s.bucket) + "/" + escapedKey
+	} else {
+		target.Host = s.bucket + "." + target.Host
+		target.Path = basePath + "/" + key
+		target.RawPath = basePath + "/" + escapedKey
+	}
+	return &target
+}
+
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	return parsed, nil
+}
+
+// sign adds the Authorization header of an AWS Signature Version 4 request.
+func (s *S3Storage) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, signedHeaders, s.signature(now, amzDate, scope, canonicalRequest)))
+}
+
+func (s *S3Storage) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+func (s *S3Storage) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (s *S3Storage) responseError(op, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 %s %s: status %d: %s", op, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapePath URI-encodes every byte except unreserved characters and "/", as
+// SigV4 requires
+func escapePath(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '/' || isUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		vals := append([]string(nil), values[key]...)
+		sort.Strings(vals)
+		for _, val := range vals {
+			parts = append(parts, escapeQuery(key)+"="+escapeQuery(val))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapeQuery(value string) string {
+	return strings.ReplaceAll(escapePath(value), "/", "%2F")
+}
+
+func isUnreserved(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~'
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object describes a stored object.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage stores uploaded files under slash separated keys.
+type Storage interface {
+	// Put streams size bytes from r into key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens an object, the caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// URLSigner is implemented by backends that can hand out time-limited direct
+// download URLs, so files do not have to be proxied through the service.
+type URLSigner interface {
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+type Config struct {
+	Provider string
+
+	// LocalDir is the root directory of the local provider
+	LocalDir string
+
+	// S3 compatible provider (AWS S3, MinIO, ...)
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// S3ForcePathStyle addresses the bucket as endpoint/bucket/key instead
+	// of bucket.endpoint/key, MinIO needs this
+	S3ForcePathStyle bool
+	// S3PublicEndpoint is used for signed URLs when clients reach the server
+	// under another address than the service does (e.g. MinIO in Docker)
+	S3PublicEndpoint string
+}
+
+// New picks the implementation configured by STORAGE_PROVIDER.
+func New(cfg Config) (Storage, error) {
+	switch cfg.Provider {
+	case "", "local":
+		if cfg.LocalDir == "" {
+			return nil, fmt.Errorf("STORAGE_LOCAL_DIR is required for the local storage provider")
+		}
+		return NewLocalStorage(cfg.LocalDir), nil
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" || cfg.S3AccessKeyID == "" || cfg.S3SecretAccessKey == "" {
+			return nil, fmt.Errorf("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required for the s3 storage provider")
+		}
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage provider %q", cfg.Provider)
+	}
+}
+
+// URLPath is where the files route serves stored objects, object URLs
+// saved in the database are URLPath + key
+const URLPath = "/api/v1/files/"
+
+// URL returns the URL an object is served under.
+func URL(key string) string {
+	return URLPath + key
+}
+
+// KeyFromURL returns the key of a URL created by URL. URLs of files that
+// were never stored (e.g. external links) report false.
+func KeyFromURL(objectURL string) (string, bool) {
+	if !strings.HasPrefix(objectURL, URLPath) {
+		return "", false
+	}
+	key := strings.TrimPrefix(objectURL, URLPath)
+	return key, ValidKey(key)
+}
+
+// ValidKey rejects keys that could escape the storage root.
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	return path.Clean(key) == key && !strings.HasPrefix(key, "../") && key != ".."
+}
+
+// PutContentAddressed stores r under prefix/<sha256 of the content><ext> and
+// returns the key. The content is spooled to a temporary file while it is
+// hashed, so large uploads are never held in memory. Uploading the same
+// bytes twice yields the same key.
+func PutContentAddressed(ctx context.Context, store Storage, prefix, ext string, r io.Reader, contentType string) (string, error) {
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	key := path.Join(prefix, hex.EncodeToString(hash.Sum(nil))+strings.ToLower(ext))
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+
+	if err := store.Put(ctx, key, tmp, size, contentType); err != nil {
+		return "", err
+	}
+	return key, nil
+}